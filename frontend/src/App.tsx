@@ -15,6 +15,9 @@ function App() {
   const [remoteOnly, setRemoteOnly] = useState(false)
   const [selectedDiscoveredDate, setSelectedDiscoveredDate] = useState<string | null>(null)
   const [selectedPostedDate, setSelectedPostedDate] = useState<string | null>(null)
+  const [selectedState, setSelectedState] = useState<string | null>(null)
+  const [sortBy, setSortBy] = useState<'score' | 'newest'>('score')
+  const [minScore, setMinScore] = useState(0)
 
   // Debounce search query
   const debouncedSearchQuery = useDebounce(searchQuery, 300)
@@ -63,11 +66,17 @@ function App() {
     const remoteParam = params.get('remote')
     const discoveredParam = params.get('discovered')
     const postedParam = params.get('posted')
+    const stateParam = params.get('state')
+    const sortParam = params.get('sort')
+    const minScoreParam = Number(params.get('min_score'))
 
     if (qParam) setSearchQuery(qParam)
     if (remoteParam === '1') setRemoteOnly(true)
     if (discoveredParam) setSelectedDiscoveredDate(discoveredParam)
     if (postedParam) setSelectedPostedDate(postedParam)
+    if (stateParam) setSelectedState(stateParam)
+    if (sortParam === 'newest') setSortBy('newest')
+    if (minScoreParam > 0) setMinScore(minScoreParam)
   }, [])
 
   // Update URL when filters change
@@ -77,10 +86,13 @@ function App() {
     if (remoteOnly) params.set('remote', '1')
     if (selectedDiscoveredDate) params.set('discovered', selectedDiscoveredDate)
     if (selectedPostedDate) params.set('posted', selectedPostedDate)
+    if (selectedState) params.set('state', selectedState)
+    if (sortBy !== 'score') params.set('sort', sortBy)
+    if (minScore > 0) params.set('min_score', String(minScore))
 
     const newUrl = `${window.location.pathname}${params.toString() ? `?${params.toString()}` : ''}`
     window.history.replaceState(null, '', newUrl)
-  }, [debouncedSearchQuery, remoteOnly, selectedDiscoveredDate, selectedPostedDate])
+  }, [debouncedSearchQuery, remoteOnly, selectedDiscoveredDate, selectedPostedDate, selectedState, sortBy, minScore])
 
   // Extract available dates from jobs
   const availableDates = useMemo(() => {
@@ -98,6 +110,17 @@ function App() {
     }
   }, [jobs])
 
+  // US states named in any job's parsed places
+  const availableStates = useMemo(() => {
+    const stateSet = new Set<string>()
+    jobs.forEach(job => {
+      job.places?.forEach(place => {
+        if (place.state) stateSet.add(place.state)
+      })
+    })
+    return Array.from(stateSet).sort()
+  }, [jobs])
+
   // Precompute searchable text once per job
   const searchableJobs = useMemo(() => {
     return jobs.map((job) => {
@@ -108,6 +131,7 @@ function App() {
         job.tags,
         job.source,
         job.salary_raw,
+        job.description_snippet,
       ]
         .filter(Boolean)
         .join(' ')
@@ -135,10 +159,21 @@ function App() {
         // Posted date filter
         if (selectedPostedDate && job.posted_date !== selectedPostedDate) return false
 
+        // State filter
+        if (selectedState && !job.places?.some(place => place.state === selectedState)) return false
+
+        // Score filter
+        if (minScore > 0 && (job.score ?? 0) < minScore) return false
+
         return true
       })
       .map(({ job }) => job)
-  }, [searchableJobs, debouncedSearchQuery, remoteOnly, selectedDiscoveredDate, selectedPostedDate])
+      .sort((a, b) =>
+        sortBy === 'score'
+          ? (b.score ?? 0) - (a.score ?? 0)
+          : b.discovered_date.localeCompare(a.discovered_date)
+      )
+  }, [searchableJobs, debouncedSearchQuery, remoteOnly, selectedDiscoveredDate, selectedPostedDate, selectedState, sortBy, minScore])
 
   const handleRetry = () => {
     window.location.reload()
@@ -172,6 +207,13 @@ function App() {
             selectedPostedDate={selectedPostedDate}
             setSelectedPostedDate={setSelectedPostedDate}
             availableDates={availableDates}
+            selectedState={selectedState}
+            setSelectedState={setSelectedState}
+            availableStates={availableStates}
+            sortBy={sortBy}
+            setSortBy={setSortBy}
+            minScore={minScore}
+            setMinScore={setMinScore}
           />
 
           <div className="mb-4">
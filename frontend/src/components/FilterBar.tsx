@@ -11,6 +11,13 @@ type FilterBarProps = {
     discovered: string[]
     posted: string[]
   }
+  selectedState: string | null
+  setSelectedState: (state: string | null) => void
+  availableStates: string[]
+  sortBy: 'score' | 'newest'
+  setSortBy: (sort: 'score' | 'newest') => void
+  minScore: number
+  setMinScore: (score: number) => void
 }
 
 export function FilterBar({
@@ -23,6 +30,13 @@ export function FilterBar({
   selectedPostedDate,
   setSelectedPostedDate,
   availableDates,
+  selectedState,
+  setSelectedState,
+  availableStates,
+  sortBy,
+  setSortBy,
+  minScore,
+  setMinScore,
 }: FilterBarProps) {
   return (
     <div className="sticky top-0 z-20 -mx-4 sm:-mx-6 lg:-mx-8 px-4 sm:px-6 lg:px-8 py-3 backdrop-blur-xl bg-black/30 border-b border-white/10 mb-8">
@@ -66,6 +80,34 @@ export function FilterBar({
               <option key={date} value={date}>{date}</option>
             ))}
           </select>
+          <select
+            value={selectedState || ''}
+            onChange={(e) => setSelectedState(e.target.value || null)}
+            className="px-4 py-2 bg-white/5 border border-white/10 rounded-lg text-white focus:outline-none focus:ring-2 focus:ring-orange-500/50 focus:border-orange-500/50"
+          >
+            <option value="">All States</option>
+            {availableStates.map(state => (
+              <option key={state} value={state}>{state}</option>
+            ))}
+          </select>
+          <select
+            value={minScore}
+            onChange={(e) => setMinScore(Number(e.target.value))}
+            className="px-4 py-2 bg-white/5 border border-white/10 rounded-lg text-white focus:outline-none focus:ring-2 focus:ring-orange-500/50 focus:border-orange-500/50"
+          >
+            <option value={0}>Any Score</option>
+            {[50, 60, 70, 80, 90].map(score => (
+              <option key={score} value={score}>Score {score}+</option>
+            ))}
+          </select>
+          <select
+            value={sortBy}
+            onChange={(e) => setSortBy(e.target.value as 'score' | 'newest')}
+            className="px-4 py-2 bg-white/5 border border-white/10 rounded-lg text-white focus:outline-none focus:ring-2 focus:ring-orange-500/50 focus:border-orange-500/50"
+          >
+            <option value="score">Best Match</option>
+            <option value="newest">Newest</option>
+          </select>
         </div>
       </div>
     </div>
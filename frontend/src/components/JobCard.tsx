@@ -32,6 +32,14 @@ export function JobCard({ job }: JobCardProps) {
               <span className="text-sm px-3 py-1.5 bg-white/5 rounded-lg font-semibold border border-white/10">
                 {job.source}
               </span>
+              {job.score !== undefined && (
+                <span
+                  title={job.score_explanation}
+                  className="text-sm px-3 py-1.5 bg-gradient-to-r from-orange-500/20 to-purple-500/20 text-orange-300 rounded-lg font-bold border border-orange-500/30"
+                >
+                  Match {job.score}
+                </span>
+              )}
             </div>
           </div>
           <div className="flex flex-wrap gap-6 text-sm text-gray-400 mb-5">
@@ -39,7 +47,10 @@ export function JobCard({ job }: JobCardProps) {
               <MapPin className="w-5 h-5 text-purple-400" />
               <span className="text-gray-300 text-base">{job.location}</span>
               {job.is_remote_us && (
-                <span className="ml-1 px-3 py-1 bg-gradient-to-r from-cyan-500/20 to-cyan-600/20 text-cyan-300 text-xs rounded-full font-bold border border-cyan-500/30">
+                <span
+                  title={job.remote_evidence}
+                  className="ml-1 px-3 py-1 bg-gradient-to-r from-cyan-500/20 to-cyan-600/20 text-cyan-300 text-xs rounded-full font-bold border border-cyan-500/30"
+                >
                   Remote US
                 </span>
               )}
@@ -58,6 +69,10 @@ export function JobCard({ job }: JobCardProps) {
             </div>
           </div>
 
+          {job.description_snippet && (
+            <p className="text-gray-400 text-base leading-relaxed mb-5">{job.description_snippet}</p>
+          )}
+
           {tags.length > 0 && (
             <div className="flex flex-wrap gap-2">
               {tags.map((tag, i) => (
@@ -1,15 +1,36 @@
+export interface Place {
+  city?: string
+  state?: string
+  country?: string
+  remote?: boolean
+}
+
 export interface Job {
   url: string
   title: string
+  seniority?: string
+  role_family?: string
   company: string
+  company_id?: number
   location: string
+  places?: Place[]
+  workplace_type?: string
   salary_raw: string
   salary_min_usd: number | null
   salary_max_usd: number | null
+  salary_currency: string
+  salary_period: string
   source: string
   posted_date: string
   discovered_date: string
   is_remote_us: boolean
+  remote_eligibility?: 'yes' | 'no' | 'unknown'
+  remote_evidence?: string
   tags: string
+  score?: number
+  score_explanation?: string
+  employment_type: string
+  closed_at?: string
+  description_snippet?: string
 }
 
@@ -7,17 +7,25 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 	"time"
 
+	"jobsite/internal/ats"
+	"jobsite/internal/company"
+	"jobsite/internal/crawl"
 	"jobsite/internal/extract"
 	"jobsite/internal/fetch"
+	"jobsite/internal/filter"
 	"jobsite/internal/lock"
 	"jobsite/internal/model"
 	"jobsite/internal/normalize"
+	"jobsite/internal/recheck"
 	"jobsite/internal/render"
+	"jobsite/internal/score"
 	"jobsite/internal/search"
 	"jobsite/internal/store"
+	"jobsite/internal/tags"
 )
 
 // Version info (set via ldflags during build)
@@ -48,8 +56,17 @@ func main() {
 		fmt.Println("\nUsage: jobsite [MODE] [FLAGS]")
 		fmt.Println("\nModes:")
 		fmt.Println("  daily   - Run daily job search and update")
-		fmt.Println("  weekly  - Run weekly summary")
+		fmt.Println("  weekly [YYYY-Www] - Write the weekly bundle (default: current ISO week)")
 		fmt.Println("  seed    - Load seed data for testing")
+		fmt.Println("  retag   - Recompute skill tags from stored descriptions")
+		fmt.Println("  recheck - Re-fetch recent jobs and mark removed postings closed")
+		fmt.Println("  rescore - Recompute every job's score against the profile")
+		fmt.Println("  renormalize - Re-derive URLs, companies, places, remote eligibility, title classes and duplicates for stored jobs")
+		fmt.Println("  runs [N]  - List the last N recorded runs (default 10)")
+		fmt.Println("  rejections [N] - List the last N jobs the filter rules rejected (default 20)")
+		fmt.Println("  search TERM... - List open jobs mentioning every term (title, company, location, tags, description)")
+		fmt.Println("  companies - List companies with their aliases and open job counts")
+		fmt.Println("  migrate status|up - Show or apply database schema migrations")
 		fmt.Println("\nFlags:")
 		flag.PrintDefaults()
 		os.Exit(0)
@@ -61,7 +78,6 @@ func main() {
 		mode = flag.Args()[0]
 	}
 
-	serpKey := os.Getenv("SERPER_API")
 	outDir := getenv("PUBLIC_DIR", "public")
 	if *outDirFlag != "" {
 		outDir = *outDirFlag
@@ -72,10 +88,25 @@ func main() {
 	}
 	siteTitle := getenv("SITE_TITLE", "QA/SDET Roles (Remote US + Wichita)")
 	baseURL := getenv("BASE_URL", "https://jobs.example.com")
+	tagger, err := loadTagger()
+	if err != nil {
+		log.Fatalf("Failed to load skills vocabulary: %v", err)
+	}
+	if err := loadFXRates(); err != nil {
+		log.Fatalf("Failed to read FX_RATES: %v", err)
+	}
+	profile, err := loadProfile()
+	if err != nil {
+		log.Fatalf("Failed to load profile: %v", err)
+	}
+	filters, err := loadFilter()
+	if err != nil {
+		log.Fatalf("Failed to load filter rules: %v", err)
+	}
 
 	// Acquire lock for daily/weekly runs
 	var lck *lock.Lock
-	if mode == "daily" || mode == "weekly" {
+	if mode == "daily" || mode == "weekly" || mode == "retag" || mode == "recheck" || mode == "rescore" || mode == "renormalize" || mode == "migrate" {
 		var err error
 		lck, err = lock.Acquire(*lockFileFlag)
 		if err != nil {
@@ -91,125 +122,764 @@ func main() {
 		}()
 	}
 
+	if mode == "migrate" {
+		runMigrate(dbPath, flag.Args()[1:])
+		return
+	}
+
 	db, err := store.Open(dbPath)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	if mode == "runs" {
+		runRuns(db, flag.Args()[1:])
+		return
+	}
+	if mode == "search" {
+		runSearch(db, flag.Args()[1:])
+		return
+	}
+	if mode == "rejections" {
+		runRejections(db, flag.Args()[1:])
+		return
+	}
+	if err := loadCompanyAliases(db); err != nil {
+		log.Fatalf("Failed to load company aliases: %v", err)
+	}
+	if err := filters.ResolveCompanies(func(name string) (int64, error) {
+		id, _, err := store.LookupCompany(db, name)
+		return id, err
+	}); err != nil {
+		log.Fatalf("Failed to resolve blocked companies: %v", err)
+	}
+	if mode == "companies" {
+		runCompanies(db)
+		return
+	}
+	if !pipelineModes[mode] {
+		log.Fatalf("unknown command: %s", mode)
+	}
+
+	run, err := store.StartRun(db, mode)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Run %s started", run.ID)
+
+	var runErr error
 	switch mode {
 	case "daily":
 		queries := getQueries()
 		log.Printf("Using %d search queries", len(queries))
-		runDaily(db, serpKey, queries, outDir, siteTitle, baseURL)
+		boards := getBoards()
+		log.Printf("Using %d ATS boards", len(boards))
+		provider := getSearchProvider()
+		log.Printf("Using search providers: %s", provider.Name())
+		runErr = runDaily(db, run, provider, queries, boards, tagger, profile, filters, outDir, siteTitle, baseURL)
 	case "weekly":
-		// TODO: implement a weekly HTML writer; for now reuse daily with last 7 days
-		runDaily(db, serpKey, []QueryConfig{}, outDir, siteTitle, baseURL)
+		runErr = runWeekly(db, flag.Args()[1:], outDir, siteTitle, baseURL)
 	case "seed":
-		loadSeed(db, outDir, siteTitle, baseURL)
-	default:
-		log.Fatalf("unknown command: %s", mode)
+		runErr = loadSeed(db, run, profile, outDir, siteTitle, baseURL)
+	case "retag":
+		runErr = runRetag(db, tagger, profile, outDir, siteTitle, baseURL)
+	case "recheck":
+		runErr = runRecheck(db, outDir, siteTitle, baseURL)
+	case "rescore":
+		runErr = runRescore(db, profile, outDir, siteTitle, baseURL)
+	case "renormalize":
+		runErr = runRenormalize(db, profile, outDir, siteTitle, baseURL)
+	}
+
+	if err := store.FinishRun(db, run, runErr); err != nil {
+		log.Printf("Failed to record run %s: %v", run.ID, err)
+	}
+	if runErr != nil {
+		log.Fatalf("Run %s failed: %v", run.ID, runErr)
 	}
+	log.Printf("Run %s finished", run.ID)
 }
 
-func runDaily(db *store.DB, serpKey string, queries []QueryConfig, outDir, siteTitle, baseURL string) {
+// pipelineModes are the modes recorded in the runs table.
+var pipelineModes = map[string]bool{
+	"daily":       true,
+	"weekly":      true,
+	"seed":        true,
+	"retag":       true,
+	"recheck":     true,
+	"rescore":     true,
+	"renormalize": true,
+}
+
+func runDaily(db *store.DB, run *store.Run, provider search.Provider, queries []QueryConfig, boards []ats.Board, tagger *tags.Matcher, profile *score.Profile, filters *filter.Filter, outDir, siteTitle, baseURL string) error {
 	newJobsCount := 0
 	updatedJobsCount := 0
 	seen := map[string]bool{}
+	save := func(j model.Job) {
+		id, _, err := store.LookupCompany(db, j.Company)
+		if err != nil {
+			log.Printf("look up company %s: %v", j.Company, err)
+		}
+		j.CompanyID = id
+		if reason := filters.Reject(j); reason != "" {
+			run.Rejected++
+			if err := store.RecordRejection(db, j, reason, run.ID); err != nil {
+				log.Printf("record rejection %s: %v", j.URL, err)
+			}
+			return
+		}
+		j.Score, j.ScoreExplanation = profile.Score(j)
+		stats, err := store.InsertJobWithStats(db, j)
+		if err != nil {
+			log.Printf("insert %s: %v", j.URL, err)
+			return
+		}
+		if stats.DuplicateOf != "" {
+			log.Printf("%s duplicates %s", j.URL, stats.DuplicateOf)
+		}
+		if stats.Inserted > 0 {
+			newJobsCount++
+		} else if stats.Updated > 0 {
+			updatedJobsCount++
+		}
+	}
+	run.QueryCount = len(queries)
+
+	// ATS APIs first: their structured fields beat scraping the same posting later.
+	for _, b := range boards {
+		postings, err := b.Postings()
+		if err != nil {
+			log.Printf("board %s: %v", b.Name(), err)
+			continue
+		}
+		log.Printf("Board %s: %d postings", b.Name(), len(postings))
+		for _, p := range postings {
+			j := p.Job
+			j.URL = normalize.CanonicalURL(j.URL)
+			if seen[j.URL] {
+				continue
+			}
+			seen[j.URL] = true
+			if sal, ok := normalize.ParseSalary(j.SalaryRaw); ok {
+				setSalary(&j, sal)
+			}
+			if j.RemoteEligibility == "" {
+				normalize.ClassifyRemoteUS(j.Location, p.Text, false, nil).SetOn(&j)
+			}
+			j.Places, j.WorkplaceType = normalize.ParseLocation(j.Location)
+			j.Seniority, j.RoleFamily = normalize.ClassifyTitle(j.Title)
+			j.DiscoveredDate = time.Now().UTC().Format("2006-01-02")
+			j.Tags = tagger.Match(j.Title, p.Text)
+			save(j)
+		}
+	}
 
+	// Gather every query's links up front so fetching can run in parallel.
+	var links []string
 	for i, cfg := range queries {
 		log.Printf("Query %d/%d (Tier %d, %d pages): %s", i+1, len(queries), cfg.Tier, cfg.Pages, cfg.Query)
-		
+
 		// Fetch all pages for this query
 		var allLinks []string
+		rq := store.RunQuery{Query: cfg.Query}
 		for page := 0; page < cfg.Pages; page++ {
 			start := page * 20
-			links, err := search.SerpAPISearch(serpKey, cfg.Query, 20, start)
+			pageLinks, err := provider.Search(cfg.Query, 20, start)
 			if err != nil {
 				log.Printf("search error on page %d: %v", page+1, err)
+				rq.SearchErrors++
 				continue
 			}
-			allLinks = append(allLinks, links...)
-			log.Printf("Page %d/%d: Found %d links (total: %d)", page+1, cfg.Pages, len(links), len(allLinks))
+			allLinks = append(allLinks, pageLinks...)
+			log.Printf("Page %d/%d: Found %d links (total: %d)", page+1, cfg.Pages, len(pageLinks), len(allLinks))
 		}
-		
+
 		log.Printf("Total %d unique links from query %d (Tier %d)", len(allLinks), i+1, cfg.Tier)
-		
+		rq.LinksFound = len(allLinks)
+		run.Queries = append(run.Queries, rq)
+
 		for _, link := range allLinks {
 			canon := normalize.CanonicalURL(link)
 			if seen[canon] {
 				continue
 			}
 			seen[canon] = true
+			links = append(links, canon)
+		}
+	}
 
-			html, err := fetch.Get(canon)
-			if err != nil {
-				log.Printf("fetch %s: %v", canon, err)
-				continue
-			}
-
-			title, company, location, salary, posted := extract.FromHTML(html)
-			min, max := normalize.SalaryToRangeUSD(salary)
-			isRemote := normalize.IsRemoteUS(location, html)
-
-			j := model.Job{
-				URL: canon, Title: strings.TrimSpace(title), Company: strings.TrimSpace(company),
-				Location: strings.TrimSpace(location), SalaryRaw: strings.TrimSpace(salary),
-				SalaryMinUSD: min, SalaryMaxUSD: max,
-				Source: sourceFromURL(canon), PostedDate: posted,
-				DiscoveredDate: time.Now().UTC().Format("2006-01-02"),
-				IsRemoteUS:     isRemote,
-				Tags:           "appium,playwright,ci-cd,macos,ios,android",
-			}
-			stats, err := store.InsertJobWithStats(db, j)
-			if err != nil {
-				continue
-			}
-			if stats.Inserted > 0 {
-				newJobsCount++
-			} else if stats.Updated > 0 {
-				updatedJobsCount++
-			}
+	opts := getCrawlOptions()
+	log.Printf("Fetching %d links (%d workers, %d per host, %s between requests to a host)", len(links), opts.Workers, opts.PerHost, opts.HostDelay)
+	scraped := crawl.Map(links, opts, func(u string) scrapeResult { return scrapeJob(u, tagger) })
+	// Insert in link order so counters and row ids don't depend on fetch timing.
+	for _, r := range scraped {
+		switch r.failure {
+		case failFetch:
+			run.FetchFailures++
+			continue
+		case failExtract:
+			run.ExtractFailures++
+		}
+		run.PagesParsed++
+		if r.job != nil {
+			save(*r.job)
 		}
 	}
+	run.NewLinks = len(seen)
 
-	jobs, err := store.LastNDays(db, 7)
+	// Anything we didn't just see live gets re-fetched to catch takedowns.
+	recent, err := store.OpenURLs(db, getRecheckDays())
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+	var stale []string
+	for _, u := range recent {
+		if !seen[u] {
+			stale = append(stale, u)
+		}
 	}
+	closedCount := recheckJobs(db, stale)
+	run.Inserted, run.Updated = newJobsCount, updatedJobsCount
+
 	log.Printf("New jobs inserted this run: %d", newJobsCount)
+	log.Printf("Jobs marked closed this run: %d", closedCount)
 	log.Printf("Existing jobs updated this run: %d", updatedJobsCount)
+	log.Printf("Jobs rejected by filter rules this run: %d", run.Rejected)
+	dayDir, err := publishDaily(db, outDir, siteTitle, baseURL)
+	if err != nil {
+		return err
+	}
+	fmt.Println("wrote:", dayDir)
+	return nil
+}
+
+// publishDaily renders the last 7 days of open jobs into today's directory
+// and public/latest.
+func publishDaily(db *store.DB, outDir, siteTitle, baseURL string) (string, error) {
+	jobs, err := store.LastNDays(db, 7)
+	if err != nil {
+		return "", err
+	}
 	log.Printf("Total jobs in database (last 7 days): %d", len(jobs))
-	dayDir, err := render.WriteDaily(outDir, siteTitle, baseURL, jobs)
+	jobs = rankJobs(jobs)
+	feeds, err := getFeedDefs()
+	if err != nil {
+		return "", err
+	}
+	return render.WriteDaily(outDir, siteTitle, baseURL, jobs, feeds)
+}
+
+// runRuns prints the most recent runs (default 10) with their per-query counts.
+func runRuns(db *store.DB, args []string) {
+	n := 10
+	if len(args) > 0 {
+		v, err := strconv.Atoi(args[0])
+		if err != nil || v < 1 {
+			log.Fatalf("invalid run count: %s", args[0])
+		}
+		n = v
+	}
+	runs, err := store.RecentRuns(db, n)
 	if err != nil {
 		log.Fatal(err)
 	}
+	if len(runs) == 0 {
+		fmt.Println("no runs recorded")
+		return
+	}
+	for _, r := range runs {
+		status := r.Status
+		if r.Error != "" {
+			status += ": " + r.Error
+		}
+		fmt.Printf("%s  %-7s %s → %s  %s\n", r.ID, r.Mode, r.StartedAt, r.FinishedAt, status)
+		fmt.Printf("    queries=%d links=%d parsed=%d fetch_failures=%d extract_failures=%d inserted=%d updated=%d rejected=%d\n",
+			r.QueryCount, r.NewLinks, r.PagesParsed, r.FetchFailures, r.ExtractFailures, r.Inserted, r.Updated, r.Rejected)
+		for _, q := range r.Queries {
+			fmt.Printf("    %4d links, %d search errors: %s\n", q.LinksFound, q.SearchErrors, truncate(q.Query, 80))
+		}
+	}
+}
+
+// runRejections prints the jobs most recently kept out by the filter rules,
+// with the rule that caught each.
+func runRejections(db *store.DB, args []string) {
+	n := 20
+	if len(args) > 0 {
+		v, err := strconv.Atoi(args[0])
+		if err != nil || v < 1 {
+			log.Fatalf("invalid rejection count: %s", args[0])
+		}
+		n = v
+	}
+	rejections, err := store.RecentRejections(db, n)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(rejections) == 0 {
+		fmt.Println("no rejections recorded")
+		return
+	}
+	for _, r := range rejections {
+		fmt.Printf("%s  %s\n", r.RejectedAt, r.Reason)
+		fmt.Printf("    %s — %s (%s)\n", truncate(r.Title, 60), r.Company, r.Location)
+		fmt.Printf("    %s\n", r.URL)
+	}
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-1] + "…"
+}
+
+// runMigrate implements "migrate status" and "migrate up". Every other mode
+// migrates implicitly when it opens the database.
+func runMigrate(dbPath string, args []string) {
+	sub := "status"
+	if len(args) > 0 {
+		sub = args[0]
+	}
+	db, err := store.Connect(dbPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	switch sub {
+	case "status":
+		infos, err := store.MigrationStatus(db)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, m := range infos {
+			state := "pending"
+			if m.AppliedAt != "" {
+				state = "applied " + m.AppliedAt
+			}
+			fmt.Printf("%4d  %-30s %s\n", m.Version, m.Name, state)
+		}
+	case "up":
+		ran, err := store.Migrate(db)
+		for _, m := range ran {
+			fmt.Printf("applied %d: %s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(ran) == 0 {
+			fmt.Println("schema is up to date")
+		}
+	default:
+		log.Fatalf("unknown migrate command: %s (want status or up)", sub)
+	}
+}
+
+// runRecheck re-fetches recently discovered open jobs, marks removed postings
+// closed and re-renders the site without them.
+func runRecheck(db *store.DB, outDir, siteTitle, baseURL string) error {
+	urls, err := store.OpenURLs(db, getRecheckDays())
+	if err != nil {
+		return err
+	}
+	closed := recheckJobs(db, urls)
+	log.Printf("Marked %d/%d jobs closed", closed, len(urls))
+
+	dayDir, err := publishDaily(db, outDir, siteTitle, baseURL)
+	if err != nil {
+		return err
+	}
 	fmt.Println("wrote:", dayDir)
+	return nil
+}
+
+// recheckJobs checks each URL on the fetch pool and marks the removed ones
+// closed, returning how many were closed.
+func recheckJobs(db *store.DB, urls []string) int {
+	if len(urls) == 0 {
+		return 0
+	}
+	log.Printf("Rechecking %d open jobs", len(urls))
+	results := crawl.Map(urls, getCrawlOptions(), recheck.Check)
+	closed := 0
+	now := time.Now()
+	for i, r := range results {
+		switch {
+		case r.Err != nil:
+			log.Printf("recheck %s: %v", urls[i], r.Err)
+		case r.Closed:
+			if err := store.MarkClosed(db, urls[i], now); err != nil {
+				log.Printf("mark closed %s: %v", urls[i], err)
+				continue
+			}
+			log.Printf("closed %s: %s", urls[i], r.Reason)
+			closed++
+		}
+	}
+	return closed
+}
+
+// Reasons a scraped link produced no usable job.
+const (
+	failFetch   = "fetch"
+	failExtract = "extract"
+)
+
+type scrapeResult struct {
+	job     *model.Job
+	failure string // "", failFetch or failExtract
+}
+
+// scrapeJob fetches a posting page and extracts a job from it. Closed
+// postings yield neither a job nor a failure.
+func scrapeJob(canon string, tagger *tags.Matcher) scrapeResult {
+	page, err := fetch.GetPage(canon)
+	if err != nil {
+		if fetch.IsGone(err) {
+			log.Printf("skip %s: posting removed (%v)", canon, err)
+			return scrapeResult{}
+		}
+		log.Printf("fetch %s: %v", canon, err)
+		return scrapeResult{failure: failFetch}
+	}
+	if closed, reason := recheck.Closed(canon, page); closed {
+		log.Printf("skip %s: posting closed (%s)", canon, reason)
+		return scrapeResult{}
+	}
+	html := page.Body
+
+	p := extract.FromPage(canon, html)
+	if strings.TrimSpace(p.Title) == "" {
+		log.Printf("extract %s: no title found", canon)
+		return scrapeResult{failure: failExtract}
+	}
+	if expired, reason := recheck.Expired(p.ValidThrough, time.Now()); expired {
+		log.Printf("skip %s: posting closed (%s)", canon, reason)
+		return scrapeResult{}
+	}
+	text := extract.Text(html)
+	// Remote wording is judged from the title and description only, never the
+	// whole page with its footers and "about us" links. Without a description
+	// only the title and location can decide, which usually means unknown.
+	remoteText := strings.TrimSpace(p.Title)
+	if p.Description != "" {
+		remoteText += "\n" + p.Description
+		text = p.Description + " " + text
+	}
+
+	j := &model.Job{
+		URL: canon, Title: strings.TrimSpace(p.Title), Company: strings.TrimSpace(p.Company),
+		Location: strings.TrimSpace(p.Location), SalaryRaw: strings.TrimSpace(p.Salary),
+		Source: sourceFromURL(canon), PostedDate: p.DatePosted,
+		DiscoveredDate:  time.Now().UTC().Format("2006-01-02"),
+		Tags:            tagger.Match(p.Title, text),
+		EmploymentType:  p.EmploymentType,
+		Description:     p.Description,
+		DescriptionHTML: p.DescriptionHTML,
+	}
+	normalize.ClassifyRemoteUS(j.Location, remoteText, p.Telecommute, p.ApplicantLocations).SetOn(j)
+	j.Places, j.WorkplaceType = normalize.ParseLocation(j.Location)
+	j.Seniority, j.RoleFamily = normalize.ClassifyTitle(j.Title)
+	if j.WorkplaceType == "" && p.Telecommute {
+		j.WorkplaceType = normalize.WorkplaceRemote
+	}
+	if p.SalaryMax > 0 {
+		setSalary(j, normalize.NewSalary(p.SalaryMin, p.SalaryMax, p.SalaryCurrency, p.SalaryUnit))
+	} else if sal, ok := normalize.ParseSalary(p.Salary); ok {
+		setSalary(j, sal)
+	}
+	return scrapeResult{job: j}
+}
+
+// setSalary records sal's currency and period on j and fills its annual USD
+// range, unless the source already supplied one.
+func setSalary(j *model.Job, sal normalize.Salary) {
+	j.SalaryCurrency, j.SalaryPeriod = sal.Currency, sal.Period
+	if j.SalaryMinUSD == nil && j.SalaryMaxUSD == nil {
+		j.SalaryMinUSD, j.SalaryMaxUSD = sal.AnnualUSD()
+	}
 }
 
-func loadSeed(db *store.DB, outDir, siteTitle, baseURL string) {
+// runWeekly writes the bundle for the ISO week named in args, or the current
+// week, from what daily runs already stored.
+func runWeekly(db *store.DB, args []string, outDir, siteTitle, baseURL string) error {
+	day := time.Now().UTC()
+	if len(args) > 0 {
+		var err error
+		if day, err = render.ParseISOWeek(args[0]); err != nil {
+			return err
+		}
+	}
+	label, from, to := render.ISOWeek(day)
+	jobs, err := store.DiscoveredBetween(db, from, to)
+	if err != nil {
+		return err
+	}
+	closed, err := store.ClosedBetween(db, from, to)
+	if err != nil {
+		return err
+	}
+	log.Printf("Week %s (%s to %s): %d new, %d closed", label, from, to, len(jobs), len(closed))
+	jobs = rankJobs(jobs)
+	weekDir, err := render.WriteWeekly(outDir, siteTitle, baseURL, day, jobs, closed)
+	if err != nil {
+		return err
+	}
+	fmt.Println("wrote:", weekDir)
+	return nil
+}
+
+func loadSeed(db *store.DB, run *store.Run, profile *score.Profile, outDir, siteTitle, baseURL string) error {
 	f, err := os.Open("data/seed.json")
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	defer f.Close()
 	dec := json.NewDecoder(f)
 	var jobs []model.Job
 	if err := dec.Decode(&jobs); err != nil {
-		log.Fatal(err)
+		return err
+	}
+	for _, j := range jobs {
+		if j.Places == nil {
+			j.Places, j.WorkplaceType = normalize.ParseLocation(j.Location)
+		}
+		if j.RemoteEligibility == "" {
+			normalize.ClassifyRemoteUS(j.Location, j.Description, false, nil).SetOn(&j)
+		}
+		if j.Seniority == "" {
+			j.Seniority, j.RoleFamily = normalize.ClassifyTitle(j.Title)
+		}
+		j.Score, j.ScoreExplanation = profile.Score(j)
+		stats, err := store.InsertJobWithStats(db, j)
+		if err != nil {
+			continue
+		}
+		run.Inserted += int(stats.Inserted)
+		run.Updated += int(stats.Updated)
+	}
+	if _, err := publishDaily(db, outDir, siteTitle, baseURL); err != nil {
+		return err
+	}
+	fmt.Println("seeded and rendered /public/latest")
+	_ = exec.Command("bash", "-lc", "ls -la public/latest").Run()
+	return nil
+}
+
+// runRetag rewrites every stored job's tags with the current vocabulary from
+// its stored description, fetching (and keeping) the description only for
+// jobs saved before descriptions were stored, then re-renders the site.
+func runRetag(db *store.DB, tagger *tags.Matcher, profile *score.Profile, outDir, siteTitle, baseURL string) error {
+	jobs, err := store.AllJobs(db)
+	if err != nil {
+		return err
+	}
+	var missing []string
+	for _, j := range jobs {
+		if j.Description == "" {
+			missing = append(missing, j.URL)
+		}
+	}
+	log.Printf("Retagging %d stored jobs (%d need fetching)", len(jobs), len(missing))
+	fetched := crawl.Map(missing, getCrawlOptions(), func(u string) *extract.Posting {
+		html, err := fetch.Get(u)
+		if err != nil {
+			log.Printf("fetch %s: %v", u, err)
+			return nil
+		}
+		p := extract.FromPage(u, html)
+		if p.Description == "" {
+			p.Description = extract.Text(html)
+		}
+		return &p
+	})
+	pages := map[string]*extract.Posting{}
+	for i, p := range fetched {
+		if p == nil {
+			continue
+		}
+		pages[missing[i]] = p
+		if p.DescriptionHTML != "" {
+			if err := store.UpdateDescription(db, missing[i], p.Description, p.DescriptionHTML); err != nil {
+				log.Printf("update description %s: %v", missing[i], err)
+			}
+		}
 	}
+
+	updated := 0
 	for _, j := range jobs {
-		_ = store.InsertJob(db, j)
+		text := j.Description
+		if text == "" {
+			p, ok := pages[j.URL]
+			if !ok {
+				continue
+			}
+			text = p.Description
+		}
+		j.Tags = tagger.Match(j.Title, text)
+		if err := store.UpdateTags(db, j.URL, j.Tags); err != nil {
+			log.Printf("update tags %s: %v", j.URL, err)
+			continue
+		}
+		j.Description = text
+		s, why := profile.Score(j)
+		if err := store.UpdateScore(db, j.URL, s, why); err != nil {
+			log.Printf("update score %s: %v", j.URL, err)
+		}
+		updated++
 	}
-	jobs7, err := store.LastNDays(db, 7)
+	log.Printf("Retagged %d/%d jobs", updated, len(jobs))
+
+	dayDir, err := publishDaily(db, outDir, siteTitle, baseURL)
+	if err != nil {
+		return err
+	}
+	fmt.Println("wrote:", dayDir)
+	return nil
+}
+
+// runRescore recomputes every stored job's score against the current
+// profile, then re-renders the site.
+func runRescore(db *store.DB, profile *score.Profile, outDir, siteTitle, baseURL string) error {
+	jobs, err := store.AllJobs(db)
+	if err != nil {
+		return err
+	}
+	for _, j := range jobs {
+		s, why := profile.Score(j)
+		if err := store.UpdateScore(db, j.URL, s, why); err != nil {
+			return err
+		}
+	}
+	log.Printf("Rescored %d jobs", len(jobs))
+	dayDir, err := publishDaily(db, outDir, siteTitle, baseURL)
+	if err != nil {
+		return err
+	}
+	fmt.Println("wrote:", dayDir)
+	return nil
+}
+
+// runRenormalize re-derives every stored job's normalized fields with the
+// current parsers, then rescores and republishes, since scores read them.
+func runRenormalize(db *store.DB, profile *score.Profile, outDir, siteTitle, baseURL string) error {
+	n, err := store.Renormalize(db)
+	if err != nil {
+		return err
+	}
+	log.Printf("Renormalized %d jobs", n)
+	return runRescore(db, profile, outDir, siteTitle, baseURL)
+}
+
+// rankJobs applies SORT_BY ("score", the default, or "date") and MIN_SCORE
+// to the jobs about to be published.
+func rankJobs(jobs []model.Job) []model.Job {
+	minScore, err := strconv.Atoi(getenv("MIN_SCORE", "0"))
+	if err != nil {
+		log.Printf("invalid MIN_SCORE, using 0: %v", err)
+		minScore = 0
+	}
+	return render.Rank(jobs, getenv("SORT_BY", "score"), minScore)
+}
+
+// runSearch prints open jobs whose title, company, location, tags or
+// description contain every term.
+func runSearch(db *store.DB, terms []string) {
+	if len(terms) == 0 {
+		log.Fatal("usage: jobsite search TERM...")
+	}
+	jobs, err := store.Search(db, terms)
 	if err != nil {
 		log.Fatal(err)
 	}
-	_, err = render.WriteDaily(outDir, siteTitle, baseURL, jobs7)
+	if len(jobs) == 0 {
+		fmt.Println("no matching jobs")
+		return
+	}
+	for _, j := range jobs {
+		fmt.Printf("%s  %s — %s  (%s)\n    %s\n", j.DiscoveredDate, j.Title, j.Company, j.Location, j.URL)
+	}
+}
+
+func runCompanies(db *store.DB) {
+	companies, err := store.Companies(db)
 	if err != nil {
 		log.Fatal(err)
 	}
-	fmt.Println("seeded and rendered /public/latest")
-	_ = exec.Command("bash", "-lc", "ls -la public/latest").Run()
+	for _, c := range companies {
+		fmt.Printf("%5d  %-30s %3d open  %s\n", c.ID, c.Name, c.Jobs, strings.Join(c.Aliases, " | "))
+	}
+}
+
+// loadCompanyAliases applies the canonical company names and aliases in
+// COMPANY_ALIASES_FILE, if set.
+func loadCompanyAliases(db *store.DB) error {
+	path := os.Getenv("COMPANY_ALIASES_FILE")
+	if path == "" {
+		return nil
+	}
+	aliases, err := company.LoadAliases(path)
+	if err != nil {
+		return err
+	}
+	log.Printf("Using company aliases from %s (%d companies)", path, len(aliases))
+	return store.SetCompanyAliases(db, aliases)
+}
+
+// getFeedDefs returns the filtered JSON Feeds to publish, from the JSON array
+// in FEEDS_FILE or the built-in set.
+func getFeedDefs() ([]render.FeedDef, error) {
+	path := os.Getenv("FEEDS_FILE")
+	if path == "" {
+		return render.DefaultFeedDefs(), nil
+	}
+	return render.LoadFeedDefs(path)
+}
+
+// loadProfile reads the scoring profile from PROFILE_FILE, or uses the
+// built-in one when it is unset.
+func loadProfile() (*score.Profile, error) {
+	path := os.Getenv("PROFILE_FILE")
+	if path == "" {
+		return score.DefaultProfile(), nil
+	}
+	p, err := score.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Using scoring profile from %s", path)
+	return p, nil
+}
+
+// loadFilter compiles the filter rules from FILTERS_FILE, or the built-in
+// ones when it is unset.
+func loadFilter() (*filter.Filter, error) {
+	path := os.Getenv("FILTERS_FILE")
+	if path == "" {
+		return filter.New(filter.Default()), nil
+	}
+	r, err := filter.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Using filter rules from %s", path)
+	return filter.New(r), nil
+}
+
+// loadTagger builds the skill tagger from SKILLS_FILE, or the built-in
+// vocabulary when it is unset.
+func loadTagger() (*tags.Matcher, error) {
+	path := os.Getenv("SKILLS_FILE")
+	if path == "" {
+		return tags.NewMatcher(tags.Default()), nil
+	}
+	v, err := tags.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Using skills vocabulary from %s (%d tags)", path, len(v))
+	return tags.NewMatcher(v), nil
 }
 
 func sourceFromURL(u string) string {
@@ -276,6 +946,101 @@ func getQueries() []QueryConfig {
 	return defaultQueries()
 }
 
+// searchKeyEnv names the environment variable holding each provider's API key.
+var searchKeyEnv = map[string]string{
+	"serper":  "SERPER_API",
+	"serpapi": "SERPAPI_API_KEY",
+	"brave":   "BRAVE_API_KEY",
+	"bing":    "BING_API_KEY",
+}
+
+// getSearchProvider builds the provider chain from SEARCH_PROVIDERS (default
+// "serper"), skipping providers without an API key. Later providers are only
+// used when earlier ones fail.
+func getSearchProvider() *search.Fallback {
+	var providers []search.Provider
+	for _, name := range splitList(getenv("SEARCH_PROVIDERS", "serper")) {
+		name = strings.ToLower(name)
+		env, ok := searchKeyEnv[name]
+		if !ok {
+			log.Printf("Unknown search provider %q, skipping", name)
+			continue
+		}
+		p, err := search.New(name, os.Getenv(env))
+		if err != nil {
+			log.Printf("Search provider %s disabled: %v (set %s)", name, err, env)
+			continue
+		}
+		providers = append(providers, p)
+	}
+	return search.NewFallback(providers...)
+}
+
+// loadFXRates overrides the built-in currency conversion rates with FX_RATES,
+// e.g. "EUR=1.08,GBP=1.27" (USD per unit of the currency).
+func loadFXRates() error {
+	rates, err := normalize.ParseFXRates(os.Getenv("FX_RATES"))
+	if err != nil {
+		return err
+	}
+	for code, rate := range rates {
+		normalize.FXRates[code] = rate
+	}
+	return nil
+}
+
+// getRecheckDays returns how far back (RECHECK_DAYS, default 7) open jobs are
+// re-fetched to detect closed postings.
+func getRecheckDays() int {
+	if n, err := strconv.Atoi(os.Getenv("RECHECK_DAYS")); err == nil && n > 0 {
+		return n
+	}
+	return 7
+}
+
+// getCrawlOptions reads the fetch pool limits from FETCH_WORKERS,
+// FETCH_PER_HOST and FETCH_HOST_DELAY.
+func getCrawlOptions() crawl.Options {
+	opts := crawl.Options{Workers: 8, PerHost: 2, HostDelay: time.Second}
+	if n, err := strconv.Atoi(os.Getenv("FETCH_WORKERS")); err == nil && n > 0 {
+		opts.Workers = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("FETCH_PER_HOST")); err == nil && n > 0 {
+		opts.PerHost = n
+	}
+	if d, err := time.ParseDuration(os.Getenv("FETCH_HOST_DELAY")); err == nil && d >= 0 {
+		opts.HostDelay = d
+	}
+	return opts
+}
+
+// getBoards returns the ATS boards to read directly, configured as
+// comma-separated board names in GREENHOUSE_BOARDS, LEVER_COMPANIES and
+// ASHBY_ORGS.
+func getBoards() []ats.Board {
+	var boards []ats.Board
+	for _, token := range splitList(os.Getenv("GREENHOUSE_BOARDS")) {
+		boards = append(boards, ats.Greenhouse{Token: token})
+	}
+	for _, company := range splitList(os.Getenv("LEVER_COMPANIES")) {
+		boards = append(boards, ats.Lever{Company: company})
+	}
+	for _, org := range splitList(os.Getenv("ASHBY_ORGS")) {
+		boards = append(boards, ats.Ashby{Org: org})
+	}
+	return boards
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
 func getenv(k, d string) string {
 	if v := os.Getenv(k); v != "" {
 		return v
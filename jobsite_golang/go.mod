@@ -5,9 +5,7 @@ go 1.21
 require (
 	github.com/PuerkitoBio/goquery v1.8.1
 	github.com/mattn/go-sqlite3 v1.14.22
+	golang.org/x/net v0.7.0
 )
 
-require (
-	github.com/andybalholm/cascadia v1.3.1 // indirect
-	golang.org/x/net v0.7.0 // indirect
-)
+require github.com/andybalholm/cascadia v1.3.1 // indirect
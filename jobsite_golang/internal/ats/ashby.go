@@ -0,0 +1,144 @@
+package ats
+
+import (
+	"net/url"
+	"strings"
+
+	"jobsite/internal/extract"
+	"jobsite/internal/model"
+	"jobsite/internal/normalize"
+)
+
+const ashbyAPI = "https://api.ashbyhq.com/posting-api/job-board"
+
+// Ashby reads an organization's public Ashby posting API.
+type Ashby struct {
+	Org string
+	// BaseURL overrides the API root, e.g. to point at a local fake server.
+	BaseURL string
+}
+
+type ashbyComponent struct {
+	CompensationType string  `json:"compensationType"`
+	Interval         string  `json:"interval"`
+	CurrencyCode     string  `json:"currencyCode"`
+	MinValue         float64 `json:"minValue"`
+	MaxValue         float64 `json:"maxValue"`
+}
+
+type ashbyJobs struct {
+	Jobs []struct {
+		Title              string `json:"title"`
+		Location           string `json:"location"`
+		SecondaryLocations []struct {
+			Location string `json:"location"`
+		} `json:"secondaryLocations"`
+		IsListed         bool   `json:"isListed"`
+		IsRemote         bool   `json:"isRemote"`
+		WorkplaceType    string `json:"workplaceType"`
+		EmploymentType   string `json:"employmentType"`
+		PublishedAt      string `json:"publishedAt"`
+		JobURL           string `json:"jobUrl"`
+		DescriptionPlain string `json:"descriptionPlain"`
+		DescriptionHTML  string `json:"descriptionHtml"`
+		Address          struct {
+			PostalAddress struct {
+				AddressCountry string `json:"addressCountry"`
+			} `json:"postalAddress"`
+		} `json:"address"`
+		Compensation *struct {
+			CompensationTierSummary             string `json:"compensationTierSummary"`
+			ScrapeableCompensationSalarySummary string `json:"scrapeableCompensationSalarySummary"`
+			CompensationTiers                   []struct {
+				Components []ashbyComponent `json:"components"`
+			} `json:"compensationTiers"`
+			SummaryComponents []ashbyComponent `json:"summaryComponents"`
+		} `json:"compensation"`
+	} `json:"jobs"`
+}
+
+func (a Ashby) Name() string { return "ashby:" + a.Org }
+
+func (a Ashby) Postings() ([]Posting, error) {
+	base := a.BaseURL
+	if base == "" {
+		base = ashbyAPI
+	}
+	var resp ashbyJobs
+	if err := getJSON(strings.TrimRight(base, "/")+"/"+url.PathEscape(a.Org)+"?includeCompensation=true", &resp); err != nil {
+		return nil, err
+	}
+	out := make([]Posting, 0, len(resp.Jobs))
+	for _, p := range resp.Jobs {
+		if !p.IsListed || p.JobURL == "" {
+			continue
+		}
+		var locs []string
+		if l := strings.TrimSpace(p.Location); l != "" {
+			locs = append(locs, l)
+		}
+		for _, sl := range p.SecondaryLocations {
+			if l := strings.TrimSpace(sl.Location); l != "" {
+				locs = append(locs, l)
+			}
+		}
+		workplace := ""
+		if p.IsRemote || p.WorkplaceType == "Remote" {
+			workplace = "Remote"
+		} else if p.WorkplaceType == "Hybrid" {
+			workplace = "Hybrid"
+		}
+		text := strings.Join(strings.Fields(p.DescriptionPlain), " ")
+
+		j := model.Job{
+			URL:             p.JobURL,
+			Title:           strings.TrimSpace(p.Title),
+			Company:         a.Org,
+			Location:        withWorkplace(workplace, strings.Join(locs, "; ")),
+			Source:          "Ashby",
+			PostedDate:      day(p.PublishedAt),
+			EmploymentType:  extract.AshbyEmploymentTypes[p.EmploymentType],
+			Description:     text,
+			DescriptionHTML: extract.Sanitize(p.DescriptionHTML),
+		}
+		if c := p.Compensation; c != nil {
+			j.SalaryRaw = c.ScrapeableCompensationSalarySummary
+			if j.SalaryRaw == "" {
+				j.SalaryRaw = c.CompensationTierSummary
+			}
+			// Span every tier's annual USD salary; other currencies and
+			// intervals are left for the raw-text parser.
+			comps := c.SummaryComponents
+			for _, t := range c.CompensationTiers {
+				comps = append(comps, t.Components...)
+			}
+			var min, max float64
+			for _, comp := range comps {
+				if comp.CompensationType != "Salary" || comp.CurrencyCode != "USD" || comp.Interval != "1 YEAR" || comp.MaxValue <= 0 {
+					continue
+				}
+				if min == 0 || comp.MinValue < min {
+					min = comp.MinValue
+				}
+				if comp.MaxValue > max {
+					max = comp.MaxValue
+				}
+			}
+			if max > 0 {
+				lo, hi := int(min), int(max)
+				j.SalaryMinUSD, j.SalaryMaxUSD = &lo, &hi
+				if j.SalaryRaw == "" {
+					j.SalaryRaw = salaryRange(min, max, "USD", "per year")
+				}
+			}
+		}
+		country := p.Address.PostalAddress.AddressCountry
+		var countries []string
+		if country != "" {
+			countries = []string{country}
+		}
+		normalize.ClassifyRemoteUS(j.Location, text, workplace == "Remote", countries).SetOn(&j)
+		out = append(out, Posting{Job: j, Text: text})
+	}
+	return out, nil
+}
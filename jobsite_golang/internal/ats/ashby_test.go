@@ -0,0 +1,66 @@
+package ats
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const ashbyBoard = `{"jobs": [
+  {"title": "Senior SDET", "location": "", "secondaryLocations": [{"location": "New York, NY"}, {"location": "Austin, TX"}],
+   "isListed": true, "isRemote": false, "workplaceType": "Hybrid", "employmentType": "FullTime",
+   "publishedAt": "2026-10-01T12:00:00.000Z", "jobUrl": "https://jobs.ashbyhq.com/acme/1111",
+   "descriptionPlain": "Build  test\nframeworks.", "descriptionHtml": "<p>Build test frameworks.</p><script>x</script>",
+   "compensation": {"compensationTierSummary": "$150K – $180K",
+     "compensationTiers": [
+       {"components": [{"compensationType": "Salary", "interval": "1 YEAR", "currencyCode": "USD", "minValue": 150000, "maxValue": 180000}]},
+       {"components": [{"compensationType": "Salary", "interval": "1 YEAR", "currencyCode": "USD", "minValue": 140000, "maxValue": 170000},
+                       {"compensationType": "EquityPercentage", "interval": "1 YEAR", "minValue": 0.1, "maxValue": 0.2}]}]}},
+  {"title": "QA Engineer", "location": "Remote", "isListed": true, "isRemote": true, "employmentType": "Contract",
+   "jobUrl": "https://jobs.ashbyhq.com/acme/2222", "address": {"postalAddress": {"addressCountry": "United States"}}},
+  {"title": "Unlisted", "location": "Remote", "isListed": false, "jobUrl": "https://jobs.ashbyhq.com/acme/3333"}
+]}`
+
+func TestAshbyPostings(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/acme" || r.URL.Query().Get("includeCompensation") != "true" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(ashbyBoard))
+	}))
+	defer srv.Close()
+
+	postings, err := Ashby{Org: "acme", BaseURL: srv.URL}.Postings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(postings) != 2 {
+		t.Fatalf("got %d postings, want 2 (unlisted skipped)", len(postings))
+	}
+
+	j := postings[0].Job
+	if j.Location != "Hybrid - New York, NY; Austin, TX" {
+		t.Errorf("Location = %q", j.Location)
+	}
+	if j.EmploymentType != "Full-time" || j.PostedDate != "2026-10-01" || j.Source != "Ashby" {
+		t.Errorf("EmploymentType, PostedDate, Source = %q, %q, %q", j.EmploymentType, j.PostedDate, j.Source)
+	}
+	if j.SalaryMinUSD == nil || *j.SalaryMinUSD != 140000 || *j.SalaryMaxUSD != 180000 {
+		t.Errorf("salary = %v - %v, want 140000 - 180000 across tiers", j.SalaryMinUSD, j.SalaryMaxUSD)
+	}
+	if j.SalaryRaw != "$150K – $180K" {
+		t.Errorf("SalaryRaw = %q", j.SalaryRaw)
+	}
+	if postings[0].Text != "Build test frameworks." {
+		t.Errorf("Text = %q", postings[0].Text)
+	}
+
+	j = postings[1].Job
+	if j.Location != "Remote" || j.EmploymentType != "Contract" {
+		t.Errorf("Location, EmploymentType = %q, %q", j.Location, j.EmploymentType)
+	}
+	if j.RemoteEligibility != "yes" {
+		t.Errorf("RemoteEligibility = %q (%q), want yes from the US postal address", j.RemoteEligibility, j.RemoteEvidence)
+	}
+}
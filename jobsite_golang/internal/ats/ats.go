@@ -0,0 +1,94 @@
+package ats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"jobsite/internal/model"
+)
+
+// Board lists every open posting for one company straight from its ATS API,
+// without going through search.
+type Board interface {
+	Name() string
+	Postings() ([]Posting, error)
+}
+
+// Posting is a job mapped from an ATS API plus the plain text of its
+// description, which the pipeline uses for salary, remote and tag detection.
+type Posting struct {
+	Job  model.Job
+	Text string
+}
+
+var client = &http.Client{Timeout: 25 * time.Second}
+
+func getJSON(url string, v any) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; JobsiteBot/1.0)")
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// day reduces an RFC 3339 timestamp to its UTC date, or returns "" when it
+// can't be parsed.
+func day(ts string) string {
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02")
+}
+
+// withWorkplace prefixes loc with a workplace type such as "Remote" unless loc
+// already says so.
+func withWorkplace(workplace, loc string) string {
+	switch {
+	case workplace == "" || strings.Contains(strings.ToLower(loc), strings.ToLower(workplace)):
+		return loc
+	case loc == "":
+		return workplace
+	default:
+		return workplace + " - " + loc
+	}
+}
+
+// salaryRange renders a structured range the way postings usually print it,
+// e.g. "$120,000 - $150,000 per year" or "EUR 90,000 - 110,000 per year".
+func salaryRange(min, max float64, currency, period string) string {
+	var s string
+	if currency == "" || strings.EqualFold(currency, "USD") {
+		s = "$" + thousands(min) + " - $" + thousands(max)
+	} else {
+		s = strings.ToUpper(currency) + " " + thousands(min) + " - " + thousands(max)
+	}
+	if period != "" {
+		s += " " + period
+	}
+	return s
+}
+
+func thousands(v float64) string {
+	s := strconv.FormatInt(int64(v), 10)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
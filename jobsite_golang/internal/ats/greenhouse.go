@@ -0,0 +1,80 @@
+package ats
+
+import (
+	"html"
+	"net/url"
+	"strings"
+
+	"jobsite/internal/extract"
+	"jobsite/internal/model"
+)
+
+const greenhouseAPI = "https://boards-api.greenhouse.io/v1/boards"
+
+// Greenhouse reads a public Greenhouse Job Board API board.
+type Greenhouse struct {
+	Token string
+	// BaseURL overrides the API root, e.g. to point at a local fake server.
+	BaseURL string
+}
+
+type greenhouseBoard struct {
+	Name string `json:"name"`
+}
+
+type greenhouseJobs struct {
+	Jobs []struct {
+		Title       string `json:"title"`
+		UpdatedAt   string `json:"updated_at"`
+		AbsoluteURL string `json:"absolute_url"`
+		Content     string `json:"content"`
+		Location    struct {
+			Name string `json:"name"`
+		} `json:"location"`
+	} `json:"jobs"`
+}
+
+func (g Greenhouse) Name() string { return "greenhouse:" + g.Token }
+
+func (g Greenhouse) Postings() ([]Posting, error) {
+	base := g.BaseURL
+	if base == "" {
+		base = greenhouseAPI
+	}
+	boardURL := strings.TrimRight(base, "/") + "/" + url.PathEscape(g.Token)
+
+	// The board name is only cosmetic; fall back to the token if it's unavailable.
+	company := g.Token
+	var board greenhouseBoard
+	if err := getJSON(boardURL, &board); err == nil && board.Name != "" {
+		company = board.Name
+	}
+
+	var resp greenhouseJobs
+	if err := getJSON(boardURL+"/jobs?content=true", &resp); err != nil {
+		return nil, err
+	}
+	out := make([]Posting, 0, len(resp.Jobs))
+	for _, j := range resp.Jobs {
+		if j.AbsoluteURL == "" {
+			continue
+		}
+		// content is entity-escaped HTML.
+		text, desc := extract.Description(html.UnescapeString(j.Content))
+		out = append(out, Posting{
+			Job: model.Job{
+				URL:             j.AbsoluteURL,
+				Title:           strings.TrimSpace(j.Title),
+				Company:         company,
+				Location:        strings.TrimSpace(j.Location.Name),
+				SalaryRaw:       extract.Salary(text),
+				Source:          "Greenhouse",
+				PostedDate:      day(j.UpdatedAt),
+				Description:     text,
+				DescriptionHTML: desc,
+			},
+			Text: text,
+		})
+	}
+	return out, nil
+}
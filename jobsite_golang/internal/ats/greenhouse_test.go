@@ -0,0 +1,83 @@
+package ats
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const greenhouseJobsJSON = `{"jobs": [
+  {"title": " QA Automation Engineer ", "updated_at": "2026-10-02T09:30:00-04:00",
+   "absolute_url": "https://boards.greenhouse.io/acme/jobs/123",
+   "content": "&lt;p&gt;Pay: $120,000 - $150,000 per year.&lt;/p&gt;&lt;p&gt;Remote - US&lt;/p&gt;",
+   "location": {"name": "Remote - US"}},
+  {"title": "No URL", "absolute_url": ""}
+]}`
+
+func TestGreenhousePostings(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/acme":
+			w.Write([]byte(`{"name": "Acme Corp"}`))
+		case "/acme/jobs":
+			if r.URL.Query().Get("content") != "true" {
+				t.Errorf("jobs requested without content=true")
+			}
+			w.Write([]byte(greenhouseJobsJSON))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	postings, err := Greenhouse{Token: "acme", BaseURL: srv.URL}.Postings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(postings) != 1 {
+		t.Fatalf("got %d postings, want 1", len(postings))
+	}
+	j := postings[0].Job
+	if j.URL != "https://boards.greenhouse.io/acme/jobs/123" || j.Title != "QA Automation Engineer" || j.Company != "Acme Corp" {
+		t.Errorf("URL, Title, Company = %q, %q, %q", j.URL, j.Title, j.Company)
+	}
+	if j.Location != "Remote - US" || j.Source != "Greenhouse" || j.PostedDate != "2026-10-02" {
+		t.Errorf("Location, Source, PostedDate = %q, %q, %q", j.Location, j.Source, j.PostedDate)
+	}
+	if j.SalaryRaw == "" {
+		t.Errorf("SalaryRaw empty, want the range from the description")
+	}
+	if postings[0].Text == "" || j.DescriptionHTML == "" {
+		t.Errorf("description not extracted: text %q, html %q", postings[0].Text, j.DescriptionHTML)
+	}
+}
+
+func TestGreenhouseBoardNameFallback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/acme/jobs" {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(greenhouseJobsJSON))
+	}))
+	defer srv.Close()
+
+	postings, err := Greenhouse{Token: "acme", BaseURL: srv.URL}.Postings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(postings) != 1 {
+		t.Fatalf("got %d postings, want 1", len(postings))
+	}
+	if postings[0].Job.Company != "acme" {
+		t.Errorf("company = %q, want the token when the board name is unavailable", postings[0].Job.Company)
+	}
+}
+
+func TestGreenhouseError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	if _, err := (Greenhouse{Token: "gone", BaseURL: srv.URL}).Postings(); err == nil {
+		t.Error("Postings on a missing board returned no error")
+	}
+}
@@ -0,0 +1,130 @@
+package ats
+
+import (
+	"html"
+	"net/url"
+	"strings"
+	"time"
+
+	"jobsite/internal/extract"
+	"jobsite/internal/model"
+	"jobsite/internal/normalize"
+)
+
+const leverAPI = "https://api.lever.co/v0/postings"
+
+// Lever reads a company's public Lever postings API.
+type Lever struct {
+	Company string
+	// BaseURL overrides the API root, e.g. to point at a local fake server.
+	BaseURL string
+}
+
+type leverPosting struct {
+	Text             string `json:"text"`
+	HostedURL        string `json:"hostedUrl"`
+	CreatedAt        int64  `json:"createdAt"` // milliseconds since the epoch
+	WorkplaceType    string `json:"workplaceType"`
+	Country          string `json:"country"`
+	Description      string `json:"description"`
+	DescriptionPlain string `json:"descriptionPlain"`
+	Additional       string `json:"additional"`
+	AdditionalPlain  string `json:"additionalPlain"`
+	Lists            []struct {
+		Text    string `json:"text"`
+		Content string `json:"content"`
+	} `json:"lists"`
+	Categories struct {
+		Location     string   `json:"location"`
+		Commitment   string   `json:"commitment"`
+		AllLocations []string `json:"allLocations"`
+	} `json:"categories"`
+	SalaryRange *struct {
+		Currency string  `json:"currency"`
+		Interval string  `json:"interval"`
+		Min      float64 `json:"min"`
+		Max      float64 `json:"max"`
+	} `json:"salaryRange"`
+}
+
+func (l Lever) Name() string { return "lever:" + l.Company }
+
+func (l Lever) Postings() ([]Posting, error) {
+	base := l.BaseURL
+	if base == "" {
+		base = leverAPI
+	}
+	var resp []leverPosting
+	if err := getJSON(strings.TrimRight(base, "/")+"/"+url.PathEscape(l.Company)+"?mode=json", &resp); err != nil {
+		return nil, err
+	}
+	out := make([]Posting, 0, len(resp))
+	for _, p := range resp {
+		if p.HostedURL == "" {
+			continue
+		}
+		parts := []string{p.DescriptionPlain}
+		desc := p.Description
+		for _, li := range p.Lists {
+			parts = append(parts, li.Text, extract.Text(li.Content))
+			desc += "<h3>" + html.EscapeString(li.Text) + "</h3><ul>" + li.Content + "</ul>"
+		}
+		desc += p.Additional
+		parts = append(parts, p.AdditionalPlain)
+		text := strings.Join(strings.Fields(strings.Join(parts, "\n")), " ")
+
+		j := model.Job{
+			URL:             p.HostedURL,
+			Title:           strings.TrimSpace(p.Text),
+			Company:         l.Company,
+			Location:        leverLocation(p),
+			Source:          "Lever",
+			EmploymentType:  strings.TrimSpace(p.Categories.Commitment),
+			Description:     text,
+			DescriptionHTML: extract.Sanitize(desc),
+		}
+		if p.CreatedAt > 0 {
+			j.PostedDate = time.UnixMilli(p.CreatedAt).UTC().Format("2006-01-02")
+		}
+		if r := p.SalaryRange; r != nil && r.Max > 0 {
+			j.SalaryRaw = salaryRange(r.Min, r.Max, r.Currency, leverInterval(r.Interval))
+			// Only annual USD ranges are comparable with the rest of the table.
+			if strings.EqualFold(r.Currency, "USD") && r.Interval == "per-year-salary" {
+				min, max := int(r.Min), int(r.Max)
+				j.SalaryMinUSD, j.SalaryMaxUSD = &min, &max
+			}
+		} else {
+			j.SalaryRaw = extract.Salary(text)
+		}
+		var countries []string
+		if p.Country != "" {
+			countries = []string{p.Country}
+		}
+		normalize.ClassifyRemoteUS(j.Location, text, p.WorkplaceType == "remote", countries).SetOn(&j)
+		out = append(out, Posting{Job: j, Text: text})
+	}
+	return out, nil
+}
+
+// leverLocation joins all of a posting's locations and prefixes the workplace
+// type, which Lever keeps separate from the location name.
+func leverLocation(p leverPosting) string {
+	loc := strings.TrimSpace(p.Categories.Location)
+	if len(p.Categories.AllLocations) > 1 {
+		loc = strings.Join(p.Categories.AllLocations, "; ")
+	}
+	var workplace string
+	switch p.WorkplaceType {
+	case "remote":
+		workplace = "Remote"
+	case "hybrid":
+		workplace = "Hybrid"
+	}
+	return withWorkplace(workplace, loc)
+}
+
+// leverInterval turns "per-year-salary" or "per-hour-wage" into "per year" / "per hour".
+func leverInterval(interval string) string {
+	interval = strings.TrimSuffix(strings.TrimSuffix(interval, "-salary"), "-wage")
+	return strings.ReplaceAll(interval, "-", " ")
+}
@@ -0,0 +1,65 @@
+package ats
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const leverPostingsJSON = `[
+  {"text": "Senior SDET", "hostedUrl": "https://jobs.lever.co/acme/aaaa-1111",
+   "createdAt": 1790000000000, "workplaceType": "remote", "country": "US",
+   "description": "<p>Join us.</p>", "descriptionPlain": "Join us.",
+   "lists": [{"text": "Requirements", "content": "<li>Playwright</li><li>Go</li>"}],
+   "additional": "<p>Benefits.</p>", "additionalPlain": "Benefits.",
+   "categories": {"location": "New York, NY", "commitment": "Full-time", "allLocations": ["New York, NY", "Austin, TX"]},
+   "salaryRange": {"currency": "USD", "interval": "per-year-salary", "min": 140000, "max": 170000}},
+  {"text": "QA Lead", "hostedUrl": "https://jobs.lever.co/acme/bbbb-2222", "workplaceType": "hybrid",
+   "categories": {"location": "London"},
+   "salaryRange": {"currency": "GBP", "interval": "per-year-salary", "min": 70000, "max": 90000}},
+  {"text": "No URL"}
+]`
+
+func TestLeverPostings(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/acme" || r.URL.Query().Get("mode") != "json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(leverPostingsJSON))
+	}))
+	defer srv.Close()
+
+	postings, err := Lever{Company: "acme", BaseURL: srv.URL}.Postings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(postings) != 2 {
+		t.Fatalf("got %d postings, want 2", len(postings))
+	}
+
+	j := postings[0].Job
+	if j.Location != "Remote - New York, NY; Austin, TX" {
+		t.Errorf("Location = %q", j.Location)
+	}
+	if j.EmploymentType != "Full-time" || j.Source != "Lever" || j.PostedDate != "2026-09-21" {
+		t.Errorf("EmploymentType, Source, PostedDate = %q, %q, %q", j.EmploymentType, j.Source, j.PostedDate)
+	}
+	if j.SalaryRaw != "$140,000 - $170,000 per year" || j.SalaryMinUSD == nil || *j.SalaryMinUSD != 140000 {
+		t.Errorf("salary = %q, min %v", j.SalaryRaw, j.SalaryMinUSD)
+	}
+	if postings[0].Text != "Join us. Requirements Playwright Go Benefits." {
+		t.Errorf("Text = %q", postings[0].Text)
+	}
+	if j.RemoteEligibility != "yes" {
+		t.Errorf("RemoteEligibility = %q (%q)", j.RemoteEligibility, j.RemoteEvidence)
+	}
+
+	j = postings[1].Job
+	if j.Location != "Hybrid - London" {
+		t.Errorf("Location = %q", j.Location)
+	}
+	if j.SalaryRaw != "GBP 70,000 - 90,000 per year" || j.SalaryMinUSD != nil {
+		t.Errorf("non-USD salary = %q, min %v; want raw text only", j.SalaryRaw, j.SalaryMinUSD)
+	}
+}
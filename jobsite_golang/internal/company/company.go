@@ -0,0 +1,76 @@
+package company
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Aliases maps a canonical company name to other names it is posted under,
+// e.g. {"Acme": ["acme-labs", "ACME Robotics, Inc."]}.
+type Aliases map[string][]string
+
+// LoadAliases reads Aliases from a JSON file.
+func LoadAliases(path string) (Aliases, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var a Aliases
+	if err := json.Unmarshal(b, &a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// legalSuffixes are dropped from the end of company names.
+var legalSuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "ltd": true, "limited": true, "corp": true,
+	"corporation": true, "co": true, "company": true, "plc": true, "gmbh": true, "ag": true,
+	"sa": true, "bv": true, "lp": true, "llp": true, "pbc": true, "pty": true,
+}
+
+var (
+	nonWordRe = regexp.MustCompile(`[^a-z0-9+#]+`)
+	slugRe    = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+	// suffixRe matches a trailing legal suffix with its comma and dots,
+	// as in "Acme, Inc." or "Acme Co".
+	suffixRe = regexp.MustCompile(`(?i)[\s,]+(?:inc|incorporated|llc|l\.l\.c|ltd|limited|corp|corporation|co|company|plc|gmbh|ag|s\.a|sa|b\.v|bv|lp|llp|pbc|pty)\.?$`)
+)
+
+// Key identifies a company across spellings: lowercased, punctuation and
+// legal suffixes removed, so "Acme, Inc.", "ACME" and "acme" share one key.
+func Key(name string) string {
+	words := strings.Fields(nonWordRe.ReplaceAllString(strings.ToLower(name), " "))
+	for len(words) > 1 && legalSuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, "")
+}
+
+// IsSlug reports whether name looks like an ATS board slug ("acme-labs")
+// rather than a name someone typed.
+func IsSlug(name string) bool {
+	return slugRe.MatchString(name) && strings.ToLower(name) == name
+}
+
+// DisplayName tidies a posted company name: legal suffixes and extra space
+// go, and board slugs are spelled out, so "acme-labs" shows as "Acme Labs".
+func DisplayName(name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	if IsSlug(name) {
+		words := strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' || r == '.' })
+		for i, w := range words {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+		return strings.Join(words, " ")
+	}
+	for {
+		trimmed := strings.TrimSpace(suffixRe.ReplaceAllString(name, ""))
+		if trimmed == name || trimmed == "" {
+			return name
+		}
+		name = trimmed
+	}
+}
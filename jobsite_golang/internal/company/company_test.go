@@ -0,0 +1,68 @@
+package company
+
+import "testing"
+
+func TestKey(t *testing.T) {
+	tests := []struct{ names []string }{
+		{[]string{"Acme, Inc.", "ACME", "acme", "Acme Inc", "  Acme  LLC ", "Acme Co."}},
+		{[]string{"Acme Labs", "acme-labs", "ACME LABS, INC."}},
+		{[]string{"C++ Shop", "c++ shop"}},
+	}
+	for _, tt := range tests {
+		want := Key(tt.names[0])
+		for _, n := range tt.names[1:] {
+			if got := Key(n); got != want {
+				t.Errorf("Key(%q) = %q, want %q as for %q", n, got, want, tt.names[0])
+			}
+		}
+	}
+	if Key("Acme") == Key("Acme Labs") {
+		t.Error("Acme and Acme Labs share a key")
+	}
+	// A name that is only a suffix keeps it rather than vanishing.
+	if got := Key("Company"); got != "company" {
+		t.Errorf("Key(Company) = %q", got)
+	}
+}
+
+func TestIsSlug(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"acme", true},
+		{"acme-labs", true},
+		{"acme_labs.io", true},
+		{"Acme", false},
+		{"acme labs", false},
+		{"Acme Labs", false},
+		{"-acme", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsSlug(tt.name); got != tt.want {
+			t.Errorf("IsSlug(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDisplayName(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"acme-labs", "Acme Labs"},
+		{"acme", "Acme"},
+		{"globex_corp", "Globex Corp"},
+		{"Acme, Inc.", "Acme"},
+		{"Acme Robotics, Inc.", "Acme Robotics"},
+		{"Globex Corporation", "Globex"},
+		{"Initech LLC", "Initech"},
+		{"Foo Co, Ltd.", "Foo"},
+		{"  Acme   Labs  ", "Acme Labs"},
+		{"ACME", "ACME"},
+		{"Company", "Company"},
+	}
+	for _, tt := range tests {
+		if got := DisplayName(tt.in); got != tt.want {
+			t.Errorf("DisplayName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package crawl
+
+import (
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Options bounds how hard a crawl may hit the network.
+type Options struct {
+	Workers   int           // concurrent calls overall
+	PerHost   int           // concurrent calls against any one host
+	HostDelay time.Duration // minimum gap between call starts on one host
+}
+
+// Map calls fn for every URL on a pool of opts.Workers goroutines while
+// respecting the per-host limits, and returns the results in the order of
+// urls regardless of completion order. A worker always takes the earliest
+// URL whose host is free, so a busy or throttled host never holds up the
+// others.
+func Map[T any](urls []string, opts Options, fn func(url string) T) []T {
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+	if opts.PerHost < 1 {
+		opts.PerHost = 1
+	}
+	out := make([]T, len(urls))
+	s := newScheduler(urls, opts)
+
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i, h, ok := s.next()
+				if !ok {
+					return
+				}
+				out[i] = fn(urls[i])
+				s.done(h)
+			}
+		}()
+	}
+	wg.Wait()
+	return out
+}
+
+// scheduler hands out URL indices, per host in order, to whichever worker
+// asks next.
+type scheduler struct {
+	perHost int
+	delay   time.Duration
+	mu      sync.Mutex
+	cond    *sync.Cond
+	hosts   []*host
+	left    int
+}
+
+// host is one host's queue of pending indices and its limits' state.
+type host struct {
+	queue  []int
+	active int
+	next   time.Time // earliest start allowed by HostDelay
+}
+
+func newScheduler(urls []string, opts Options) *scheduler {
+	s := &scheduler{perHost: opts.PerHost, delay: opts.HostDelay, left: len(urls)}
+	s.cond = sync.NewCond(&s.mu)
+	byName := map[string]*host{}
+	for i, raw := range urls {
+		name := raw
+		if u, err := url.Parse(raw); err == nil {
+			name = u.Hostname()
+		}
+		h, ok := byName[name]
+		if !ok {
+			h = &host{}
+			byName[name] = h
+			s.hosts = append(s.hosts, h)
+		}
+		h.queue = append(h.queue, i)
+	}
+	return s
+}
+
+// next blocks until some host may take another call and returns its
+// earliest pending index, or false once every index has been handed out.
+func (s *scheduler) next() (int, *host, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.left > 0 {
+		now := time.Now()
+		var pick *host
+		var wake time.Time
+		for _, h := range s.hosts {
+			if len(h.queue) == 0 || h.active >= s.perHost {
+				continue
+			}
+			if h.next.After(now) {
+				if wake.IsZero() || h.next.Before(wake) {
+					wake = h.next
+				}
+				continue
+			}
+			if pick == nil || h.queue[0] < pick.queue[0] {
+				pick = h
+			}
+		}
+		if pick != nil {
+			i := pick.queue[0]
+			pick.queue = pick.queue[1:]
+			pick.active++
+			pick.next = now.Add(s.delay)
+			s.left--
+			return i, pick, true
+		}
+		if !wake.IsZero() {
+			time.AfterFunc(time.Until(wake), s.wake)
+		}
+		s.cond.Wait()
+	}
+	return 0, nil, false
+}
+
+// done frees h's slot for the next call.
+func (s *scheduler) done(h *host) {
+	s.mu.Lock()
+	h.active--
+	s.mu.Unlock()
+	s.cond.Broadcast()
+}
+
+func (s *scheduler) wake() {
+	s.mu.Lock()
+	s.cond.Broadcast()
+	s.mu.Unlock()
+}
@@ -0,0 +1,90 @@
+package crawl
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMapKeepsOrder(t *testing.T) {
+	var urls []string
+	for i := 0; i < 20; i++ {
+		urls = append(urls, fmt.Sprintf("https://h%d.example/%d", i%3, i))
+	}
+	got := Map(urls, Options{Workers: 4, PerHost: 2}, func(u string) string {
+		time.Sleep(time.Duration(len(u)%5) * time.Millisecond)
+		return u
+	})
+	for i := range urls {
+		if got[i] != urls[i] {
+			t.Fatalf("result %d = %q, want %q", i, got[i], urls[i])
+		}
+	}
+}
+
+func TestMapPerHostLimit(t *testing.T) {
+	var mu sync.Mutex
+	active, peak := map[string]int{}, map[string]int{}
+	var urls []string
+	for i := 0; i < 12; i++ {
+		urls = append(urls, fmt.Sprintf("https://h%d.example/%d", i%2, i))
+	}
+	Map(urls, Options{Workers: 8, PerHost: 2}, func(raw string) bool {
+		u, _ := url.Parse(raw)
+		mu.Lock()
+		active[u.Host]++
+		if active[u.Host] > peak[u.Host] {
+			peak[u.Host] = active[u.Host]
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		active[u.Host]--
+		mu.Unlock()
+		return true
+	})
+	for h, n := range peak {
+		if n != 2 {
+			t.Errorf("%s peaked at %d concurrent calls, want 2", h, n)
+		}
+	}
+}
+
+func TestMapHostDelay(t *testing.T) {
+	const delay = 20 * time.Millisecond
+	var mu sync.Mutex
+	starts := map[string][]time.Time{}
+	urls := []string{"https://a.example/1", "https://a.example/2", "https://a.example/3", "https://b.example/1", "https://b.example/2"}
+	Map(urls, Options{Workers: 4, PerHost: 4, HostDelay: delay}, func(raw string) bool {
+		u, _ := url.Parse(raw)
+		mu.Lock()
+		starts[u.Host] = append(starts[u.Host], time.Now())
+		mu.Unlock()
+		return true
+	})
+	for h, ts := range starts {
+		for i := 1; i < len(ts); i++ {
+			if gap := ts[i].Sub(ts[i-1]); gap < delay-time.Millisecond {
+				t.Errorf("%s: calls %d and %d started %v apart, want at least %v", h, i-1, i, gap, delay)
+			}
+		}
+	}
+}
+
+func TestMapBusyHostDoesNotBlockOthers(t *testing.T) {
+	const slow = 100 * time.Millisecond
+	urls := []string{"https://slow.example/1", "https://slow.example/2", "https://slow.example/3", "https://fast.example/1"}
+	begin := time.Now()
+	started := Map(urls, Options{Workers: 2, PerHost: 1}, func(raw string) time.Duration {
+		at := time.Since(begin)
+		if u, _ := url.Parse(raw); u.Host == "slow.example" {
+			time.Sleep(slow)
+		}
+		return at
+	})
+	if started[3] >= slow/2 {
+		t.Errorf("fast host started after %v, waiting on the slow one", started[3])
+	}
+}
@@ -1,86 +1,141 @@
 package extract
 
 import (
-	"encoding/json"
+	"net/url"
 	"regexp"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
+
+	"jobsite/internal/normalize"
 )
 
-var salaryPats = []*regexp.Regexp{
-	regexp.MustCompile(`\$\s?\d{2,3}(?:,\d{3})?\s*[-–]\s*\$\s?\d{2,3}(?:,\d{3})?`),
-	regexp.MustCompile(`\$\s?\d{2,3}(?:,\d{3})?\s*(?:per year|annually|/year)`),
-	regexp.MustCompile(`\$\s?\d{2,3}k(?:\s*[-–]\s*\$\s?\d{2,3}k)?`),
-}
+// salaryRe finds pay figures in any of the common currencies, with an
+// optional range, trailing currency code and pay period (the one group).
+var salaryRe = regexp.MustCompile(`(?i)` +
+	`(?:(?:\b[a-z]{1,3})?\$|€|£|₹|\b(?:usd|cad|eur|gbp|aud)\s?)\s?\d{1,3}(?:[,.]\d{3})*(?:\.\d{1,2})?(?:\s?k\b)?` +
+	`(?:\s*(?:[-–—]|to)\s*(?:(?:\b[a-z]{1,3})?\$|€|£|₹|(?:usd|cad|eur|gbp|aud)\s?)?\s?\d{1,3}(?:[,.]\d{3})*(?:\.\d{1,2})?(?:\s?k\b)?)?` +
+	`(?:\s?(?:usd|cad|eur|gbp|aud)\b)?` +
+	`(\s*(?:/\s?(?:hr|hour|yr|year|mo|month|day|wk|week)\b|per\s+(?:hour|year|annum|month|day|week)|an\s+hour|a\s+year|annually|hourly|monthly))?`)
+
+// Posting is what could be read off a job page. Values from a schema.org
+// JobPosting block win over DOM heuristics.
+type Posting struct {
+	Title      string
+	Company    string
+	Location   string
+	Salary     string
+	DatePosted string
+
+	EmploymentType     string // e.g. "Full-time, Contract"
+	ValidThrough       string // YYYY-MM-DD when parseable; see recheck.Expired
+	Identifier         string // the employer's own id for the posting, e.g. a requisition number
+	Description        string // plain text
+	DescriptionHTML    string
+	Telecommute        bool // jobLocationType TELECOMMUTE
+	Addresses          []Address
+	ApplicantLocations []string // applicantLocationRequirements, e.g. "United States"
 
-type jsonLD struct {
-	Title              string `json:"title"`
-	HiringOrganization struct {
-		Name string `json:"name"`
-	} `json:"hiringOrganization"`
-	DatePosted string `json:"datePosted"`
+	// Structured baseSalary, if the page had one.
+	SalaryMin      float64
+	SalaryMax      float64
+	SalaryCurrency string // ISO 4217
+	SalaryUnit     string // HOUR, DAY, WEEK, MONTH or YEAR
 }
 
-func FromHTML(html string) (title, company, location, salary, datePosted string) {
+// FromPage extracts the posting at rawURL: JSON-LD first, then the extractor
+// registered for the URL's host fills whatever is still missing.
+func FromPage(rawURL, html string) (p Posting) {
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
 	if err != nil {
 		return
 	}
 
-	if h1 := strings.TrimSpace(doc.Find("h1").First().Text()); h1 != "" {
-		title = h1
-	} else {
-		title = strings.TrimSpace(doc.Find("title").First().Text())
-	}
-
-	// JSON-LD
+	// JSON-LD: a JobPosting may be the whole block, part of a top-level
+	// array, or nested in an @graph.
 	doc.Find(`script[type="application/ld+json"]`).Each(func(i int, s *goquery.Selection) {
-		var jd jsonLD
-		if err := json.Unmarshal([]byte(strings.TrimSpace(s.Text())), &jd); err == nil {
-			if jd.HiringOrganization.Name != "" && company == "" {
-				company = jd.HiringOrganization.Name
-			}
-			if jd.Title != "" && title == "" {
-				title = jd.Title
-			}
-			if jd.DatePosted != "" && datePosted == "" {
-				datePosted = jd.DatePosted
-			}
+		for _, m := range jobPostingsLD(strings.TrimSpace(s.Text())) {
+			fromJobPostingLD(&p, m)
 		}
 	})
+	p.Location = structuredLocation(p)
+	p.Salary = structuredSalary(p)
 
-	if company == "" {
-		if x := strings.TrimSpace(doc.Find(".company, .company-name, .app-title small, .posting-headline h3").First().Text()); x != "" {
-			company = x
-		}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		u = &url.URL{}
 	}
+	ExtractorFor(u).Extract(u, doc, &p)
 
-	if x := strings.TrimSpace(doc.Find(":contains('Location')").Next().Text()); x != "" {
-		location = x
+	// Every ATS renders the title somewhere obvious; don't give up on a
+	// posting just because a site extractor's markup went stale.
+	if p.Title == "" {
+		p.Title = genericTitle(doc)
 	}
-	if location == "" {
-		doc.Find("li, p, span, div").EachWithBreak(func(_ int, s *goquery.Selection) bool {
-			t := strings.TrimSpace(s.Text())
-			if t == "" {
-				return true
-			}
-			lt := strings.ToLower(t)
-			if strings.Contains(lt, "remote") || strings.Contains(t, "Wichita") || strings.Contains(t, "United States") {
-				location = t
-				return false
-			}
-			return true
-		})
+
+	if p.Salary == "" {
+		p.Salary = Salary(doc.Text())
 	}
 
-	full := doc.Text()
-	for _, re := range salaryPats {
-		if m := re.FindString(full); m != "" {
-			salary = strings.TrimSpace(m)
-			break
+	return
+}
+
+// structuredLocation joins the JobPosting addresses, falling back to
+// "Remote" (with any applicant countries) for telecommute-only postings.
+func structuredLocation(p Posting) string {
+	var locs []string
+	for _, a := range p.Addresses {
+		if s := a.String(); s != "" {
+			locs = append(locs, s)
 		}
 	}
+	if p.Telecommute {
+		remote := "Remote"
+		if len(p.ApplicantLocations) > 0 {
+			remote += " (" + strings.Join(p.ApplicantLocations, ", ") + ")"
+		}
+		locs = append(locs, remote)
+	}
+	return strings.Join(locs, "; ")
+}
 
-	return
+// Salary returns the first phrase in text that reads as a plausible salary
+// (between 10,000 and 2,000,000 a year in its own currency), or "". Small
+// amounts only count with an explicit period, so "$100 bonus" is skipped.
+func Salary(text string) string {
+	for _, m := range salaryRe.FindAllStringSubmatch(text, -1) {
+		sal, ok := normalize.ParseSalary(m[0])
+		if !ok || (sal.Max < 1000 && m[1] == "") {
+			continue
+		}
+		if _, max := sal.Annual(); max >= 10000 && max <= 2000000 {
+			return strings.TrimSpace(m[0])
+		}
+	}
+	return ""
+}
+
+// Text returns the visible text of a page with scripts and styles removed and
+// whitespace collapsed.
+func Text(html string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, n := range doc.Nodes {
+		nodeText(&b, n)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// Description sanitizes raw description HTML and returns it with its plain
+// text.
+func Description(raw string) (text, sanitized string) {
+	sanitized = Sanitize(raw)
+	return Text(sanitized), sanitized
+}
+
+func setDescription(p *Posting, raw string) {
+	p.Description, p.DescriptionHTML = Description(raw)
 }
@@ -0,0 +1,348 @@
+package extract
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Extractor fills in the fields a page's JSON-LD left empty, using markup
+// specific to one ATS.
+type Extractor interface {
+	Name() string
+	Extract(u *url.URL, doc *goquery.Document, p *Posting)
+}
+
+// extractorHosts mirrors search.allowedHosts; extractorSuffixes covers ATSs
+// that give each company its own subdomain.
+var extractorHosts = map[string]Extractor{
+	"boards.greenhouse.io":        greenhouseExtractor,
+	"job-boards.greenhouse.io":    greenhouseExtractor,
+	"job-boards.eu.greenhouse.io": greenhouseExtractor,
+	"jobs.lever.co":               leverExtractor,
+	"jobs.eu.lever.co":            leverExtractor,
+	"jobs.ashbyhq.com":            ashbyExtractor{},
+	"apply.workable.com":          workableExtractor,
+	"jobs.smartrecruiters.com":    smartRecruitersExtractor,
+}
+
+var extractorSuffixes = []struct {
+	suffix string
+	ex     Extractor
+}{
+	{".myworkdayjobs.com", workdayExtractor},
+	{".icims.com", icimsExtractor},
+	{".bamboohr.com", bambooHRExtractor},
+	{".recruitee.com", recruiteeExtractor},
+	{".breezy.hr", breezyExtractor},
+}
+
+// ExtractorFor returns the extractor registered for u's host, or the generic
+// one.
+func ExtractorFor(u *url.URL) Extractor {
+	h := strings.ToLower(u.Hostname())
+	if ex, ok := extractorHosts[h]; ok {
+		return ex
+	}
+	for _, s := range extractorSuffixes {
+		if strings.HasSuffix(h, s.suffix) {
+			return s.ex
+		}
+	}
+	return genericExtractor{}
+}
+
+// siteExtractor reads fields with CSS selectors tried in order. A selector
+// ending in "@attr" reads that attribute instead of the element's text.
+// description selectors take the inner HTML of every element the first
+// matching selector finds.
+type siteExtractor struct {
+	name        string
+	title       []string
+	company     []string
+	location    []string
+	description []string
+	slug        func(u *url.URL) string // company fallback taken from the URL
+}
+
+func (s siteExtractor) Name() string { return s.name }
+
+func (s siteExtractor) Extract(u *url.URL, doc *goquery.Document, p *Posting) {
+	fill(&p.Title, pick(doc, s.title))
+	fill(&p.Company, strings.TrimPrefix(pick(doc, s.company), "at "))
+	fill(&p.Location, pick(doc, s.location))
+	if p.Description == "" {
+		if h := pickHTML(doc, s.description); h != "" {
+			setDescription(p, h)
+		}
+	}
+	if s.slug != nil {
+		fill(&p.Company, s.slug(u))
+	}
+}
+
+var greenhouseExtractor = siteExtractor{
+	name:        "greenhouse",
+	title:       []string{".job__title h1", ".app-title", "h1"},
+	company:     []string{".company-name", `meta[property="og:site_name"]@content`},
+	location:    []string{".job__location", "#header .location", ".location"},
+	description: []string{".job__description", "#content"},
+	slug: func(u *url.URL) string {
+		if f := u.Query().Get("for"); f != "" { // embed/job_app?for=acme
+			return f
+		}
+		return pathSlug(u)
+	},
+}
+
+var leverExtractor = siteExtractor{
+	name:        "lever",
+	title:       []string{".posting-headline h2", "h2"},
+	company:     []string{`.main-header-logo img@alt`},
+	location:    []string{".posting-categories .location", ".posting-categories .sort-by-location"},
+	description: []string{".section-wrapper.page-full-width", `[data-qa="job-description"]`},
+	slug:        pathSlug,
+}
+
+var workableExtractor = siteExtractor{
+	name:        "workable",
+	title:       []string{`[data-ui="job-title"]`, "h1"},
+	company:     []string{`meta[property="og:site_name"]@content`},
+	location:    []string{`[data-ui="job-location"]`},
+	description: []string{`[data-ui="job-description"], [data-ui="job-requirements"], [data-ui="job-benefits"]`},
+	slug:        pathSlug,
+}
+
+var workdayExtractor = siteExtractor{
+	name:        "workday",
+	title:       []string{`[data-automation-id="jobPostingHeader"]`, `meta[property="og:title"]@content`},
+	company:     []string{`meta[property="og:site_name"]@content`},
+	location:    []string{`[data-automation-id="locations"] dd`, `[data-automation-id="location"]`},
+	description: []string{`[data-automation-id="jobPostingDescription"]`},
+	slug:        hostSlug,
+}
+
+var smartRecruitersExtractor = siteExtractor{
+	name:        "smartrecruiters",
+	title:       []string{"h1.job-title", `[itemprop="title"]`},
+	company:     []string{`[itemprop="hiringOrganization"] [itemprop="name"]@content`, `meta[property="og:site_name"]@content`},
+	location:    []string{"spl-job-location@formattedaddress", `[itemprop="jobLocation"]`, ".job-detail-location"},
+	description: []string{`[itemprop="description"]`, ".job-sections"},
+	slug:        pathSlug,
+}
+
+var icimsExtractor = siteExtractor{
+	name:        "icims",
+	title:       []string{"h1.iCIMS_Header", ".iCIMS_Header h1", "h1"},
+	company:     []string{`meta[property="og:site_name"]@content`},
+	location:    []string{".iCIMS_JobHeaderTag:contains('Location') .iCIMS_JobHeaderData", ".header.left span:not(.field-label)"},
+	description: []string{".iCIMS_JobContent", ".iCIMS_InfoMsg_Job"},
+	slug: func(u *url.URL) string {
+		return strings.TrimPrefix(hostSlug(u), "careers-")
+	},
+}
+
+var bambooHRExtractor = siteExtractor{
+	name:        "bamboohr",
+	title:       []string{".posting-title", "h2", `meta[property="og:title"]@content`},
+	company:     []string{`meta[property="og:site_name"]@content`},
+	location:    []string{".posCatInfo .location", `[class*="Location"]`, `[class*="location"]`},
+	description: []string{".BambooRichText", ".ResAts__listing-description"},
+	slug:        hostSlug,
+}
+
+var recruiteeExtractor = siteExtractor{
+	name:        "recruitee",
+	title:       []string{".job-title", "h1"},
+	company:     []string{`meta[property="og:site_name"]@content`},
+	location:    []string{`[data-testid="job-location"]`, ".job-location", ".job-tags .location"},
+	description: []string{`[data-testid="job-description"]`, ".job-description", ".description"},
+	slug:        hostSlug,
+}
+
+var breezyExtractor = siteExtractor{
+	name:        "breezy",
+	title:       []string{".position h1", "h1"},
+	company:     []string{`meta[property="og:site_name"]@content`, ".brand-header h1"},
+	location:    []string{".position .location span", ".position .location"},
+	description: []string{".position .description", ".description"},
+	slug:        hostSlug,
+}
+
+// ashbyExtractor reads the posting from the window.__appData blob Ashby's
+// single-page app is rendered from; the static HTML has little else.
+type ashbyExtractor struct{}
+
+// AshbyEmploymentTypes maps Ashby's employmentType values to the labels
+// used everywhere else. The posting API adapter in package ats shares it.
+var AshbyEmploymentTypes = map[string]string{
+	"FullTime":  "Full-time",
+	"PartTime":  "Part-time",
+	"Intern":    "Intern",
+	"Contract":  "Contract",
+	"Temporary": "Temporary",
+}
+
+func (ashbyExtractor) Name() string { return "ashby" }
+
+func (ashbyExtractor) Extract(u *url.URL, doc *goquery.Document, p *Posting) {
+	var data struct {
+		Organization struct {
+			Name string `json:"name"`
+		} `json:"organization"`
+		Posting *struct {
+			Title                   string `json:"title"`
+			LocationName            string `json:"locationName"`
+			IsRemote                bool   `json:"isRemote"`
+			WorkplaceType           string `json:"workplaceType"`
+			EmploymentType          string `json:"employmentType"`
+			DescriptionHTML         string `json:"descriptionHtml"`
+			PublishedDate           string `json:"publishedDate"`
+			CompensationTierSummary string `json:"compensationTierSummary"`
+		} `json:"posting"`
+	}
+	doc.Find("script").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		t := s.Text()
+		i := strings.Index(t, "__appData")
+		if i < 0 {
+			return true
+		}
+		j := strings.Index(t[i:], "{")
+		if j < 0 {
+			return true
+		}
+		json.NewDecoder(strings.NewReader(t[i+j:])).Decode(&data)
+		return false
+	})
+
+	fill(&p.Company, data.Organization.Name)
+	if post := data.Posting; post != nil {
+		fill(&p.Title, post.Title)
+		fill(&p.Location, post.LocationName)
+		fill(&p.DatePosted, ldDate(post.PublishedDate))
+		fill(&p.EmploymentType, AshbyEmploymentTypes[post.EmploymentType])
+		fill(&p.Salary, post.CompensationTierSummary)
+		if p.Description == "" && post.DescriptionHTML != "" {
+			setDescription(p, post.DescriptionHTML)
+		}
+		if post.IsRemote || post.WorkplaceType == "Remote" {
+			p.Telecommute = true
+		}
+	}
+	fill(&p.Company, pathSlug(u))
+}
+
+// genericExtractor is the catch-all for hosts without their own extractor.
+type genericExtractor struct{}
+
+func (genericExtractor) Name() string { return "generic" }
+
+func (genericExtractor) Extract(u *url.URL, doc *goquery.Document, p *Posting) {
+	fill(&p.Title, genericTitle(doc))
+	fill(&p.Company, strings.TrimSpace(doc.Find(".company, .company-name, .app-title small, .posting-headline h3").First().Text()))
+	if p.Location == "" {
+		p.Location = genericLocation(doc)
+	}
+	if p.Description == "" {
+		if h := pickHTML(doc, []string{`[itemprop="description"]`, ".job-description", "#job-description", ".description", "article", "main"}); h != "" {
+			setDescription(p, h)
+		}
+	}
+}
+
+func genericTitle(doc *goquery.Document) string {
+	if h1 := strings.TrimSpace(doc.Find("h1").First().Text()); h1 != "" {
+		return h1
+	}
+	return strings.TrimSpace(doc.Find("title").First().Text())
+}
+
+func genericLocation(doc *goquery.Document) (location string) {
+	if x := strings.TrimSpace(doc.Find(":contains('Location')").Next().Text()); x != "" {
+		return x
+	}
+	doc.Find("li, p, span, div").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		t := strings.TrimSpace(s.Text())
+		if t == "" {
+			return true
+		}
+		lt := strings.ToLower(t)
+		if strings.Contains(lt, "remote") || strings.Contains(t, "Wichita") || strings.Contains(t, "United States") {
+			location = t
+			return false
+		}
+		return true
+	})
+	return
+}
+
+// pick returns the first non-empty value the selectors yield.
+func pick(doc *goquery.Document, selectors []string) string {
+	for _, sel := range selectors {
+		attr := ""
+		if i := strings.LastIndex(sel, "@"); i > 0 {
+			sel, attr = sel[:i], sel[i+1:]
+		}
+		var v string
+		doc.Find(sel).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+			if attr != "" {
+				v, _ = s.Attr(attr)
+			} else {
+				v = s.Text()
+			}
+			v = strings.Join(strings.Fields(v), " ")
+			return v == ""
+		})
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// pickHTML returns the inner HTML of every element matched by the first
+// selector that finds any text.
+func pickHTML(doc *goquery.Document, selectors []string) string {
+	for _, sel := range selectors {
+		var parts []string
+		doc.Find(sel).Each(func(_ int, s *goquery.Selection) {
+			if strings.TrimSpace(s.Text()) == "" {
+				return
+			}
+			if h, err := s.Html(); err == nil {
+				parts = append(parts, h)
+			}
+		})
+		if len(parts) > 0 {
+			return strings.Join(parts, "\n")
+		}
+	}
+	return ""
+}
+
+func fill(dst *string, v string) {
+	if *dst == "" {
+		*dst = strings.TrimSpace(v)
+	}
+}
+
+// pathSlug is the first path segment, e.g. "acme" in jobs.lever.co/acme/123.
+func pathSlug(u *url.URL) string {
+	for _, seg := range strings.Split(u.Path, "/") {
+		if seg != "" {
+			return seg
+		}
+	}
+	return ""
+}
+
+// hostSlug is the first host label, e.g. "acme" in acme.breezy.hr.
+func hostSlug(u *url.URL) string {
+	h := u.Hostname()
+	if i := strings.Index(h, "."); i > 0 {
+		return h[:i]
+	}
+	return ""
+}
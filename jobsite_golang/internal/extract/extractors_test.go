@@ -0,0 +1,71 @@
+package extract
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestExtractorFor(t *testing.T) {
+	tests := []struct{ link, want string }{
+		{"https://boards.greenhouse.io/acme/jobs/1", "greenhouse"},
+		{"https://job-boards.eu.greenhouse.io/acme/jobs/1", "greenhouse"},
+		{"https://jobs.eu.lever.co/acme/1", "lever"},
+		{"https://acme.wd5.myworkdayjobs.com/en-US/careers/job/QA_R1", "workday"},
+		{"https://jobs.smartrecruiters.com/Acme/123-sdet", "smartrecruiters"},
+		{"https://careers-acme.icims.com/jobs/1/job", "icims"},
+		{"https://acme.com/careers/1", "generic"},
+	}
+	for _, tt := range tests {
+		u, _ := url.Parse(tt.link)
+		if got := ExtractorFor(u).Name(); got != tt.want {
+			t.Errorf("ExtractorFor(%q) = %s, want %s", tt.link, got, tt.want)
+		}
+	}
+}
+
+func TestFromPageSiteExtractors(t *testing.T) {
+	tests := []struct {
+		name, url, html                 string
+		title, company, location, descr string
+	}{
+		{
+			// Workday pages have no h1; the generic extractor used to take
+			// the tenant's site chrome for the title.
+			name: "workday",
+			url:  "https://acme.wd5.myworkdayjobs.com/en-US/careers/job/Remote-USA/Senior-SDET_R123",
+			html: `<html><head><title>Careers at Acme</title><meta property="og:title" content="Senior SDET"></head><body>
+<div data-automation-id="jobPostingHeader">Senior SDET</div>
+<div data-automation-id="locations"><dt>Locations</dt><dd>Remote - USA</dd></div>
+<div data-automation-id="jobPostingDescription"><p>Own our Playwright suite.</p></div>
+<footer>Location: Acme HQ, Dublin</footer></body></html>`,
+			title: "Senior SDET", company: "acme", location: "Remote - USA", descr: "Own our Playwright suite.",
+		},
+		{
+			name: "smartrecruiters",
+			url:  "https://jobs.smartrecruiters.com/AcmeCorp/744000012345-qa-automation-engineer",
+			html: `<html><body><header><h1>AcmeCorp Careers</h1></header>
+<h1 class="job-title">QA Automation Engineer</h1>
+<div itemprop="hiringOrganization"><meta itemprop="name" content="Acme Corp"></div>
+<spl-job-location formattedaddress="Wichita, KS, United States"></spl-job-location>
+<div itemprop="description"><p>Automate everything.</p></div></body></html>`,
+			title: "QA Automation Engineer", company: "Acme Corp", location: "Wichita, KS, United States", descr: "Automate everything.",
+		},
+		{
+			name: "generic",
+			url:  "https://careers.acme.com/jobs/42",
+			html: `<html><head><title>Jobs</title></head><body>
+<h1>Test Engineer</h1><div class="company">Acme</div>
+<span>Location</span><span>Remote, United States</span>
+<div class="job-description"><p>Write tests.</p></div></body></html>`,
+			title: "Test Engineer", company: "Acme", location: "Remote, United States", descr: "Write tests.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := FromPage(tt.url, tt.html)
+			if p.Title != tt.title || p.Company != tt.company || p.Location != tt.location || p.Description != tt.descr {
+				t.Errorf("got title %q, company %q, location %q, description %q", p.Title, p.Company, p.Location, p.Description)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package extract
+
+import (
+	"encoding/json"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Address is one schema.org PostalAddress from a posting's jobLocation.
+type Address struct {
+	Street   string `json:"street,omitempty"`
+	Locality string `json:"locality,omitempty"`
+	Region   string `json:"region,omitempty"`
+	Country  string `json:"country,omitempty"`
+}
+
+func (a Address) String() string {
+	var parts []string
+	for _, p := range []string{a.Locality, a.Region, a.Country} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	if len(parts) == 0 && a.Street != "" {
+		return a.Street
+	}
+	return strings.Join(parts, ", ")
+}
+
+// schemaEmploymentTypes maps schema.org employmentType values to the labels
+// the ATS adapters use.
+var schemaEmploymentTypes = map[string]string{
+	"FULL_TIME":  "Full-time",
+	"PART_TIME":  "Part-time",
+	"CONTRACTOR": "Contract",
+	"TEMPORARY":  "Temporary",
+	"INTERN":     "Intern",
+	"VOLUNTEER":  "Volunteer",
+	"PER_DIEM":   "Per diem",
+	"OTHER":      "Other",
+}
+
+var salaryUnits = map[string]string{
+	"HOUR":  "per hour",
+	"DAY":   "per day",
+	"WEEK":  "per week",
+	"MONTH": "per month",
+	"YEAR":  "per year",
+}
+
+// jobPostingsLD returns every JobPosting object in a JSON-LD script body,
+// whether it is the top-level object, one element of a top-level array, or
+// nested in an @graph.
+func jobPostingsLD(raw string) []map[string]any {
+	var v any
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		return nil
+	}
+	var out []map[string]any
+	var walk func(v any)
+	walk = func(v any) {
+		switch x := v.(type) {
+		case []any:
+			for _, e := range x {
+				walk(e)
+			}
+		case map[string]any:
+			if hasType(x, "JobPosting") {
+				out = append(out, x)
+				return
+			}
+			walk(x["@graph"])
+		}
+	}
+	walk(v)
+	return out
+}
+
+func hasType(m map[string]any, want string) bool {
+	for _, t := range ldList(m["@type"]) {
+		if s, ok := t.(string); ok && (s == want || strings.HasSuffix(s, "/"+want)) {
+			return true
+		}
+	}
+	return false
+}
+
+// fromJobPostingLD fills p from a JobPosting object. Values already set on p
+// are kept, so the first posting on a page wins.
+func fromJobPostingLD(p *Posting, m map[string]any) {
+	fill(&p.Title, clean(ldText(m["title"])))
+	fill(&p.Company, clean(ldText(m["hiringOrganization"])))
+	fill(&p.DatePosted, ldDate(ldText(m["datePosted"])))
+	fill(&p.ValidThrough, ldDate(ldText(m["validThrough"])))
+	fill(&p.Identifier, ldIdentifier(m["identifier"]))
+
+	if p.Description == "" {
+		if d := ldText(m["description"]); d != "" {
+			if strings.Contains(d, "&lt;") {
+				d = html.UnescapeString(d)
+			}
+			setDescription(p, d)
+		}
+	}
+
+	if p.EmploymentType == "" {
+		var types []string
+		for _, t := range ldList(m["employmentType"]) {
+			s, _ := t.(string)
+			s = strings.TrimSpace(s)
+			if label, ok := schemaEmploymentTypes[strings.ToUpper(strings.ReplaceAll(s, "-", "_"))]; ok {
+				s = label
+			}
+			if s != "" {
+				types = append(types, s)
+			}
+		}
+		p.EmploymentType = strings.Join(types, ", ")
+	}
+
+	for _, t := range ldList(m["jobLocationType"]) {
+		if s, _ := t.(string); strings.EqualFold(s, "TELECOMMUTE") {
+			p.Telecommute = true
+		}
+	}
+	if len(p.Addresses) == 0 {
+		for _, loc := range ldList(m["jobLocation"]) {
+			if a, ok := ldAddress(loc); ok {
+				p.Addresses = append(p.Addresses, a)
+			}
+		}
+	}
+	if len(p.ApplicantLocations) == 0 {
+		for _, r := range ldList(m["applicantLocationRequirements"]) {
+			if s := clean(ldText(r)); s != "" {
+				p.ApplicantLocations = append(p.ApplicantLocations, s)
+			}
+		}
+	}
+
+	if p.SalaryMax == 0 {
+		ldSalary(p, m["baseSalary"])
+	}
+}
+
+// ldSalary reads a MonetaryAmount (or a bare number) into p.
+func ldSalary(p *Posting, v any) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		if n, ok := ldNumber(v); ok {
+			p.SalaryMin, p.SalaryMax = n, n
+		}
+		return
+	}
+	p.SalaryCurrency = strings.ToUpper(ldText(m["currency"]))
+	val := m["value"]
+	q, ok := val.(map[string]any)
+	if !ok {
+		if n, ok := ldNumber(val); ok {
+			p.SalaryMin, p.SalaryMax = n, n
+		}
+		p.SalaryUnit = strings.ToUpper(ldText(m["unitText"]))
+		return
+	}
+	if p.SalaryCurrency == "" {
+		p.SalaryCurrency = strings.ToUpper(ldText(q["currency"]))
+	}
+	p.SalaryUnit = strings.ToUpper(ldText(q["unitText"]))
+	if p.SalaryUnit == "" {
+		p.SalaryUnit = strings.ToUpper(ldText(m["unitText"]))
+	}
+	min, hasMin := ldNumber(q["minValue"])
+	max, hasMax := ldNumber(q["maxValue"])
+	if n, ok := ldNumber(q["value"]); ok {
+		if !hasMin {
+			min, hasMin = n, true
+		}
+		if !hasMax {
+			max, hasMax = n, true
+		}
+	}
+	switch {
+	case hasMin && hasMax:
+		p.SalaryMin, p.SalaryMax = min, max
+	case hasMin:
+		p.SalaryMin, p.SalaryMax = min, min
+	case hasMax:
+		p.SalaryMin, p.SalaryMax = max, max
+	}
+}
+
+// structuredSalary renders the baseSalary as text, e.g. "$120,000–$150,000
+// per year" or "EUR 60,000 per year".
+func structuredSalary(p Posting) string {
+	if p.SalaryMax == 0 {
+		return ""
+	}
+	money := func(n float64) string {
+		s := formatThousands(n)
+		if p.SalaryCurrency == "" || p.SalaryCurrency == "USD" {
+			return "$" + s
+		}
+		return p.SalaryCurrency + " " + s
+	}
+	out := money(p.SalaryMin)
+	if p.SalaryMax != p.SalaryMin {
+		out += "–" + money(p.SalaryMax)
+	}
+	if unit, ok := salaryUnits[p.SalaryUnit]; ok {
+		out += " " + unit
+	}
+	return out
+}
+
+func formatThousands(n float64) string {
+	if n != float64(int64(n)) {
+		return strconv.FormatFloat(n, 'f', 2, 64)
+	}
+	s := strconv.FormatInt(int64(n), 10)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// ldAddress reads a Place (or a bare address) from jobLocation.
+func ldAddress(v any) (Address, bool) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		s := clean(ldText(v))
+		return Address{Locality: s}, s != ""
+	}
+	addr := m["address"]
+	if addr == nil {
+		addr = m
+	}
+	am, ok := addr.(map[string]any)
+	if !ok {
+		s := clean(ldText(addr))
+		if s == "" {
+			s = clean(ldText(m["name"]))
+		}
+		return Address{Locality: s}, s != ""
+	}
+	a := Address{
+		Street:   clean(ldText(am["streetAddress"])),
+		Locality: clean(ldText(am["addressLocality"])),
+		Region:   clean(ldText(am["addressRegion"])),
+		Country:  clean(ldText(am["addressCountry"])),
+	}
+	return a, a != Address{}
+}
+
+// ldIdentifier reads a PropertyValue identifier's value, falling back to its
+// name, or a plain string identifier.
+func ldIdentifier(v any) string {
+	if m, ok := v.(map[string]any); ok {
+		for _, k := range []string{"value", "name"} {
+			if s := clean(ldText(m[k])); s != "" {
+				return s
+			}
+		}
+		return ""
+	}
+	return clean(ldText(v))
+}
+
+// ldList treats a single value as a one-element list.
+func ldList(v any) []any {
+	switch x := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		return x
+	default:
+		return []any{x}
+	}
+}
+
+// ldText returns the text of a JSON-LD value: a string, a number, the name or
+// value of an object, or the first non-empty element of a list.
+func ldText(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case map[string]any:
+		for _, k := range []string{"name", "@value", "value"} {
+			if s := ldText(x[k]); s != "" {
+				return s
+			}
+		}
+	case []any:
+		for _, e := range x {
+			if s := ldText(e); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+func ldNumber(v any) (float64, bool) {
+	switch x := v.(type) {
+	case float64:
+		return x, true
+	case string:
+		n, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(x), ",", ""), 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// ldDate trims ISO 8601 timestamps to their date; anything unparseable is
+// returned as-is.
+func ldDate(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 10 {
+		if _, err := time.Parse("2006-01-02", s[:10]); err == nil {
+			return s[:10]
+		}
+	}
+	return s
+}
+
+func clean(s string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
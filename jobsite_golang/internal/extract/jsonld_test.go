@@ -0,0 +1,70 @@
+package extract
+
+import (
+	"reflect"
+	"testing"
+)
+
+const jobPostingPage = `<html><head><script type="application/ld+json">
+{"@context": "https://schema.org", "@graph": [
+  {"@type": "Organization", "name": "Not the hiring org"},
+  {"@type": "JobPosting",
+   "title": "Senior SDET",
+   "hiringOrganization": {"@type": "Organization", "name": "Acme &amp; Co"},
+   "datePosted": "2026-10-01T08:00:00Z",
+   "validThrough": "2026-11-30T23:59",
+   "identifier": {"@type": "PropertyValue", "name": "Acme", "value": "REQ-1234"},
+   "employmentType": ["FULL_TIME", "CONTRACTOR"],
+   "description": "&lt;p&gt;Write &lt;b&gt;tests&lt;/b&gt;.&lt;/p&gt;",
+   "jobLocationType": "TELECOMMUTE",
+   "applicantLocationRequirements": {"@type": "Country", "name": "United States"},
+   "jobLocation": [{"@type": "Place", "address": {"@type": "PostalAddress", "addressLocality": "Wichita", "addressRegion": "KS", "postalCode": "67202", "addressCountry": "US"}}],
+   "baseSalary": {"@type": "MonetaryAmount", "currency": "USD", "value": {"@type": "QuantitativeValue", "minValue": 120000, "maxValue": 150000, "unitText": "YEAR"}}}
+]}
+</script></head><body><h1>Ignored</h1></body></html>`
+
+func TestFromPageJSONLD(t *testing.T) {
+	p := FromPage("https://example.com/jobs/1", jobPostingPage)
+	if p.Title != "Senior SDET" || p.Company != "Acme & Co" {
+		t.Errorf("Title, Company = %q, %q", p.Title, p.Company)
+	}
+	if p.DatePosted != "2026-10-01" || p.ValidThrough != "2026-11-30" {
+		t.Errorf("DatePosted, ValidThrough = %q, %q", p.DatePosted, p.ValidThrough)
+	}
+	if p.Identifier != "REQ-1234" {
+		t.Errorf("Identifier = %q", p.Identifier)
+	}
+	if p.EmploymentType != "Full-time, Contract" {
+		t.Errorf("EmploymentType = %q", p.EmploymentType)
+	}
+	if p.Description != "Write tests." {
+		t.Errorf("Description = %q", p.Description)
+	}
+	if !p.Telecommute || !reflect.DeepEqual(p.ApplicantLocations, []string{"United States"}) {
+		t.Errorf("Telecommute, ApplicantLocations = %v, %q", p.Telecommute, p.ApplicantLocations)
+	}
+	if p.Location != "Wichita, KS, US; Remote (United States)" {
+		t.Errorf("Location = %q", p.Location)
+	}
+	if p.SalaryMin != 120000 || p.SalaryMax != 150000 || p.SalaryCurrency != "USD" || p.SalaryUnit != "YEAR" {
+		t.Errorf("salary = %v-%v %s/%s", p.SalaryMin, p.SalaryMax, p.SalaryCurrency, p.SalaryUnit)
+	}
+}
+
+func TestLDIdentifier(t *testing.T) {
+	tests := []struct {
+		in   any
+		want string
+	}{
+		{map[string]any{"@type": "PropertyValue", "name": "Acme", "value": "R-1"}, "R-1"},
+		{map[string]any{"@type": "PropertyValue", "value": 4021.0}, "4021"},
+		{map[string]any{"@type": "PropertyValue", "name": "REQ 77"}, "REQ 77"},
+		{" job-9 ", "job-9"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := ldIdentifier(tt.in); got != tt.want {
+			t.Errorf("ldIdentifier(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package extract
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// keptTags survive Sanitize without attributes (links keep a safe href).
+// Any other element is unwrapped, keeping its children, except droppedTags,
+// which go together with their contents.
+var keptTags = map[string]bool{
+	"p": true, "br": true, "hr": true, "div": true,
+	"ul": true, "ol": true, "li": true, "dl": true, "dt": true, "dd": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"strong": true, "b": true, "em": true, "i": true, "u": true,
+	"blockquote": true, "pre": true, "code": true, "a": true,
+	"table": true, "thead": true, "tbody": true, "tr": true, "th": true, "td": true,
+}
+
+var droppedTags = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true,
+	"iframe": true, "object": true, "embed": true, "svg": true, "math": true,
+	"form": true, "input": true, "button": true, "select": true, "textarea": true,
+	"head": true, "title": true, "meta": true, "link": true, "img": true,
+}
+
+// blockTags start a new line of text, so Text doesn't run list items or
+// paragraphs together.
+var blockTags = map[string]bool{
+	"p": true, "br": true, "hr": true, "div": true, "section": true, "article": true,
+	"header": true, "footer": true, "main": true, "aside": true, "nav": true,
+	"ul": true, "ol": true, "li": true, "dl": true, "dt": true, "dd": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"blockquote": true, "pre": true, "table": true, "tr": true, "th": true, "td": true,
+}
+
+// Sanitize reduces an HTML fragment to a small set of formatting tags with
+// no attributes other than http(s)/mailto link targets, safe to embed in our
+// own pages.
+func Sanitize(fragment string) string {
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div})
+	if err != nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, n := range nodes {
+		writeSanitized(&b, n)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func writeSanitized(b *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(html.EscapeString(n.Data))
+		return
+	case html.ElementNode:
+	default:
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			writeSanitized(b, c)
+		}
+		return
+	}
+
+	tag := n.Data
+	if droppedTags[tag] {
+		return
+	}
+	kept := keptTags[tag]
+	if kept {
+		b.WriteString("<" + tag)
+		if tag == "a" {
+			if href := safeHref(n); href != "" {
+				b.WriteString(` href="` + html.EscapeString(href) + `" rel="nofollow noopener"`)
+			}
+		}
+		b.WriteString(">")
+		if tag == "br" || tag == "hr" {
+			return
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeSanitized(b, c)
+	}
+	if kept {
+		b.WriteString("</" + tag + ">")
+	}
+}
+
+func safeHref(n *html.Node) string {
+	for _, a := range n.Attr {
+		if a.Key != "href" {
+			continue
+		}
+		u, err := url.Parse(strings.TrimSpace(a.Val))
+		if err != nil {
+			return ""
+		}
+		switch strings.ToLower(u.Scheme) {
+		case "http", "https", "mailto":
+			return u.String()
+		}
+	}
+	return ""
+}
+
+// nodeText appends the text under n to b, breaking at block elements.
+func nodeText(b *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(n.Data)
+		return
+	case html.CommentNode:
+		return
+	case html.ElementNode:
+		switch n.Data {
+		case "script", "style", "noscript", "template":
+			return
+		}
+	}
+	block := n.Type == html.ElementNode && blockTags[n.Data]
+	if block {
+		b.WriteString("\n")
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		nodeText(b, c)
+	}
+	if block {
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,29 @@
+package extract
+
+import "testing"
+
+func TestSanitize(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{`<p>Hello <b>there</b></p>`, `<p>Hello <b>there</b></p>`},
+		{`<p>Before</p><script>alert(1)</script><p>After</p>`, `<p>Before</p><p>After</p>`},
+		{`<iframe src="https://evil.example"><p>inside</p></iframe>ok`, `ok`},
+		{`<style>p{color:red}</style><p>styled</p>`, `<p>styled</p>`},
+		{`<p onclick="steal()" class="x" style="color:red">hi</p>`, `<p>hi</p>`},
+		{`<div onmouseover="x()"><span onload="y()">text</span></div>`, `<div>text</div>`},
+		{`<a href="https://acme.com/jobs" onclick="x()">apply</a>`, `<a href="https://acme.com/jobs" rel="nofollow noopener">apply</a>`},
+		{`<a href="mailto:jobs@acme.com">mail</a>`, `<a href="mailto:jobs@acme.com" rel="nofollow noopener">mail</a>`},
+		{`<a href="javascript:alert(1)">x</a>`, `<a>x</a>`},
+		{`<a href=" JavaScript:alert(1)">x</a>`, `<a>x</a>`},
+		{`<a href="data:text/html,hi">x</a>`, `<a>x</a>`},
+		{`<img src="x" onerror="alert(1)">pic`, `pic`},
+		{`AT&amp;T &lt;script&gt;alert(1)&lt;/script&gt;`, `AT&amp;T &lt;script&gt;alert(1)&lt;/script&gt;`},
+		{`Q&A for "5" <b>engineers</b>`, `Q&amp;A for &#34;5&#34; <b>engineers</b>`},
+		{`<a href="https://acme.com/?a=1&amp;b=&quot;2&quot;">q</a>`, `<a href="https://acme.com/?a=1&amp;b=&#34;2&#34;" rel="nofollow noopener">q</a>`},
+		{`line<br>break`, `line<br>break`},
+	}
+	for _, tt := range tests {
+		if got := Sanitize(tt.in); got != tt.want {
+			t.Errorf("Sanitize(%q)\n got %q\nwant %q", tt.in, got, tt.want)
+		}
+	}
+}
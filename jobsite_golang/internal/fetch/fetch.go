@@ -1,21 +1,140 @@
 package fetch
 
 import (
+	"errors"
+	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
+	"strconv"
 	"time"
 )
 
 var client = &http.Client{Timeout: 25 * time.Second}
 
+// Retry policy for transient failures. A Retry-After longer than
+// MaxRetryAfter ends the retries instead of stalling the run.
+var (
+	MaxAttempts   = 4
+	BaseDelay     = time.Second
+	MaxDelay      = 30 * time.Second
+	MaxRetryAfter = 2 * time.Minute
+)
+
+// StatusError is returned for any non-2xx response.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	retryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("GET %s: %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Gone reports whether the posting has been removed (404 or 410).
+func (e *StatusError) Gone() bool {
+	return e.StatusCode == http.StatusNotFound || e.StatusCode == http.StatusGone
+}
+
+// Temporary reports whether the same request may succeed later.
+func (e *StatusError) Temporary() bool {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests:
+		return true
+	}
+	return e.StatusCode >= 500
+}
+
+// IsGone reports whether err means the posting no longer exists.
+func IsGone(err error) bool {
+	var se *StatusError
+	return errors.As(err, &se) && se.Gone()
+}
+
+// Page is a successfully fetched document.
+type Page struct {
+	URL  string // final URL after redirects
+	Body string
+}
+
+// Get returns the body of url, retrying network errors and temporary HTTP
+// statuses with jittered exponential backoff.
 func Get(url string) (string, error) {
-	req, _ := http.NewRequest("GET", url, nil)
+	p, err := GetPage(url)
+	if err != nil {
+		return "", err
+	}
+	return p.Body, nil
+}
+
+// GetPage is Get but also reports where redirects ended up.
+func GetPage(url string) (*Page, error) {
+	for attempt := 1; ; attempt++ {
+		p, err := get(url)
+		if err == nil {
+			return p, nil
+		}
+		if attempt >= MaxAttempts {
+			return nil, err
+		}
+		wait := backoff(attempt)
+		var se *StatusError
+		if errors.As(err, &se) {
+			if !se.Temporary() || se.retryAfter > MaxRetryAfter {
+				return nil, err
+			}
+			if se.retryAfter > 0 {
+				wait = se.retryAfter
+			}
+		}
+		time.Sleep(wait)
+	}
+}
+
+func get(url string) (*Page, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; JobsiteBot/1.0)")
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		io.Copy(io.Discard, resp.Body)
+		return nil, &StatusError{URL: url, StatusCode: resp.StatusCode, retryAfter: retryAfter(resp.Header.Get("Retry-After"))}
+	}
 	b, err := io.ReadAll(resp.Body)
-	return string(b), err
+	if err != nil {
+		return nil, err
+	}
+	return &Page{URL: resp.Request.URL.String(), Body: string(b)}, nil
+}
+
+// backoff returns a delay in [d/2, d) where d doubles each attempt up to MaxDelay.
+func backoff(attempt int) time.Duration {
+	d := BaseDelay << (attempt - 1)
+	if d > MaxDelay || d <= 0 {
+		d = MaxDelay
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
 }
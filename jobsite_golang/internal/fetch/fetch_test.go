@@ -0,0 +1,127 @@
+package fetch
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetries shrinks the backoff so tests don't sleep.
+func fastRetries(t *testing.T) {
+	t.Helper()
+	base, max := BaseDelay, MaxDelay
+	BaseDelay, MaxDelay = time.Millisecond, 5*time.Millisecond
+	t.Cleanup(func() { BaseDelay, MaxDelay = base, max })
+}
+
+// serve answers with statuses in turn, then 200 "ok", counting requests.
+func serve(t *testing.T, calls *int32, statuses []int, header http.Header) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(calls, 1))
+		if n <= len(statuses) {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGetPageRetriesServerError(t *testing.T) {
+	fastRetries(t)
+	var calls int32
+	srv := serve(t, &calls, []int{http.StatusServiceUnavailable}, nil)
+	p, err := GetPage(srv.URL)
+	if err != nil || p.Body != "ok" || calls != 2 {
+		t.Errorf("GetPage = %v, %v after %d calls; want ok after 2", p, err, calls)
+	}
+}
+
+func TestGetPageHonorsRetryAfter(t *testing.T) {
+	fastRetries(t)
+	var calls int32
+	srv := serve(t, &calls, []int{http.StatusTooManyRequests}, http.Header{"Retry-After": {"1"}})
+	start := time.Now()
+	p, err := GetPage(srv.URL)
+	if err != nil || p.Body != "ok" || calls != 2 {
+		t.Fatalf("GetPage = %v, %v after %d calls; want ok after 2", p, err, calls)
+	}
+	if waited := time.Since(start); waited < time.Second {
+		t.Errorf("retried after %v, want the 1s Retry-After", waited)
+	}
+}
+
+func TestGetPageRetryAfterTooLong(t *testing.T) {
+	fastRetries(t)
+	var calls int32
+	srv := serve(t, &calls, []int{http.StatusTooManyRequests}, http.Header{"Retry-After": {"3600"}})
+	_, err := GetPage(srv.URL)
+	if err == nil || calls != 1 {
+		t.Errorf("GetPage = %v after %d calls; want an error and no retry", err, calls)
+	}
+}
+
+func TestGetPageNotFound(t *testing.T) {
+	fastRetries(t)
+	var calls int32
+	srv := serve(t, &calls, []int{http.StatusNotFound}, nil)
+	_, err := GetPage(srv.URL)
+	if !IsGone(err) || calls != 1 {
+		t.Errorf("GetPage = %v after %d calls; want gone without a retry", err, calls)
+	}
+}
+
+func TestGetPageGivesUp(t *testing.T) {
+	fastRetries(t)
+	var calls int32
+	srv := serve(t, &calls, []int{500, 502, 503, 504, 500}, nil)
+	_, err := GetPage(srv.URL)
+	if err == nil || IsGone(err) || int(calls) != MaxAttempts {
+		t.Errorf("GetPage = %v after %d calls; want an error after %d", err, calls, MaxAttempts)
+	}
+}
+
+func TestStatusErrorClasses(t *testing.T) {
+	tests := []struct {
+		code            int
+		gone, temporary bool
+	}{
+		{404, true, false},
+		{410, true, false},
+		{403, false, false},
+		{408, false, true},
+		{425, false, true},
+		{429, false, true},
+		{500, false, true},
+		{503, false, true},
+	}
+	for _, tt := range tests {
+		e := &StatusError{StatusCode: tt.code}
+		if e.Gone() != tt.gone || e.Temporary() != tt.temporary {
+			t.Errorf("%d: Gone %v Temporary %v, want %v %v", tt.code, e.Gone(), e.Temporary(), tt.gone, tt.temporary)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if d := retryAfter("30"); d != 30*time.Second {
+		t.Errorf("retryAfter(30) = %v", d)
+	}
+	date := time.Now().Add(90 * time.Second).UTC().Format(http.TimeFormat)
+	if d := retryAfter(date); d < 80*time.Second || d > 90*time.Second {
+		t.Errorf("retryAfter(%q) = %v, want about 90s", date, d)
+	}
+	past := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	for _, v := range []string{"", "0", "-5", "soon", past} {
+		if d := retryAfter(v); d != 0 {
+			t.Errorf("retryAfter(%q) = %v, want 0", v, d)
+		}
+	}
+}
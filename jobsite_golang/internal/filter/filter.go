@@ -0,0 +1,187 @@
+package filter
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"strings"
+
+	"jobsite/internal/company"
+	"jobsite/internal/model"
+	"jobsite/internal/normalize"
+)
+
+// Rules decide which extracted jobs are kept. Words and keywords match as
+// whole words, ignoring case.
+type Rules struct {
+	BlockedCompanies  []string `json:"blocked_companies"`   // matched by company.Key and company aliases
+	BlockedTitleWords []string `json:"blocked_title_words"` // e.g. "manual", "clearance"
+	RequiredKeywords  []string `json:"required_keywords"`   // title or description must mention at least one
+	ExcludedLocations []string `json:"excluded_locations"`  // e.g. "India", "CA", "Toronto, Canada"
+}
+
+// Default carries over the exclusions the search queries ask for, which
+// search engines only apply loosely and board APIs not at all.
+func Default() Rules {
+	return Rules{BlockedTitleWords: []string{"intern", "internship", "contract", "temporary"}}
+}
+
+// Load reads Rules from a JSON file.
+func Load(path string) (Rules, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Rules{}, err
+	}
+	var r Rules
+	if err := json.Unmarshal(b, &r); err != nil {
+		return Rules{}, err
+	}
+	return r, nil
+}
+
+// Filter applies compiled Rules.
+type Filter struct {
+	companies  map[string]string // key → configured name
+	companyIDs map[int64]string  // resolved company → configured name
+	titleWords []word
+	required   []word
+	locations  []location
+}
+
+type word struct {
+	text string
+	re   *regexp.Regexp
+}
+
+// location is an excluded location both as a parsed place, when it names
+// one, and as a phrase for locations that don't parse.
+type location struct {
+	word
+	place model.Place
+}
+
+// New compiles r into a Filter.
+func New(r Rules) *Filter {
+	f := &Filter{companies: map[string]string{}, companyIDs: map[int64]string{}}
+	for _, c := range r.BlockedCompanies {
+		if k := company.Key(c); k != "" {
+			f.companies[k] = strings.TrimSpace(c)
+		}
+	}
+	f.titleWords = words(r.BlockedTitleWords)
+	f.required = words(r.RequiredKeywords)
+	for _, w := range words(r.ExcludedLocations) {
+		l := location{word: w}
+		if places, _ := normalize.ParseLocation(w.text); len(places) == 1 {
+			l.place = places[0]
+		}
+		f.locations = append(f.locations, l)
+	}
+	return f
+}
+
+// ResolveCompanies looks up the company each blocked name belongs to, so
+// a job posted under any alias of that company is blocked too. lookup
+// returns 0 for a name no company uses yet.
+func (f *Filter) ResolveCompanies(lookup func(name string) (int64, error)) error {
+	for _, name := range f.companies {
+		id, err := lookup(name)
+		if err != nil {
+			return err
+		}
+		if id != 0 {
+			f.companyIDs[id] = name
+		}
+	}
+	return nil
+}
+
+func words(list []string) []word {
+	var out []word
+	for _, s := range list {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, word{s, regexp.MustCompile(`(?i)(?:^|[^\w])` + regexp.QuoteMeta(s) + `(?:$|[^\w])`)})
+		}
+	}
+	return out
+}
+
+// Reject returns why j should not be stored, or "" to keep it. Companies
+// are matched by j.CompanyID as well as by name, so set it first.
+func (f *Filter) Reject(j model.Job) string {
+	if name, ok := f.companyIDs[j.CompanyID]; ok && j.CompanyID != 0 {
+		return "blocked company: " + name
+	}
+	if name, ok := f.companies[company.Key(j.Company)]; ok {
+		return "blocked company: " + name
+	}
+	for _, w := range f.titleWords {
+		if w.re.MatchString(j.Title) {
+			return "blocked title word: " + w.text
+		}
+	}
+	if l := f.excludedLocation(j); l != "" {
+		return "excluded location: " + l
+	}
+	if len(f.required) > 0 {
+		text := j.Title + "\n" + j.Description
+		for _, w := range f.required {
+			if w.re.MatchString(text) {
+				return ""
+			}
+		}
+		return "no required keyword"
+	}
+	return ""
+}
+
+// excludedLocation returns the excluded location j is limited to. A job is
+// excluded only when every place it lists is, so "Remote - US; Pune, India"
+// survives excluding India while "Pune, India; Delhi, India" does not.
+// Places with no city, state or country, and jobs with no parsed places,
+// are checked against the location text.
+func (f *Filter) excludedLocation(j model.Job) string {
+	if len(f.locations) == 0 {
+		return ""
+	}
+	inText := ""
+	for _, l := range f.locations {
+		if l.re.MatchString(j.Location) {
+			inText = l.text
+			break
+		}
+	}
+	if len(j.Places) == 0 {
+		return inText
+	}
+	hit := ""
+	for _, pl := range j.Places {
+		m := ""
+		if pl.City == "" && pl.State == "" && pl.Country == "" {
+			m = inText
+		} else {
+			for _, l := range f.locations {
+				if within(pl, l.place) {
+					m = l.text
+					break
+				}
+			}
+		}
+		if m == "" {
+			return ""
+		}
+		hit = m
+	}
+	return hit
+}
+
+// within reports whether place p lies in the excluded place x: every part
+// x names must agree.
+func within(p, x model.Place) bool {
+	if x.City == "" && x.State == "" && x.Country == "" {
+		return false
+	}
+	return (x.City == "" || strings.EqualFold(p.City, x.City)) &&
+		(x.State == "" || strings.EqualFold(p.State, x.State)) &&
+		(x.Country == "" || strings.EqualFold(p.Country, x.Country))
+}
@@ -0,0 +1,62 @@
+package filter
+
+import (
+	"testing"
+
+	"jobsite/internal/model"
+	"jobsite/internal/normalize"
+)
+
+func TestReject(t *testing.T) {
+	f := New(Rules{
+		BlockedCompanies:  []string{"Acme, Inc."},
+		BlockedTitleWords: []string{"manual", "clearance"},
+		ExcludedLocations: []string{"India", "Toronto, Canada"},
+	})
+	tests := []struct {
+		title, company, location string
+		rejected                 bool
+	}{
+		{"QA Engineer", "ACME", "Remote - US", true},
+		{"QA Engineer", "Globex", "Remote - US", false},
+		{"Manual QA Tester", "Globex", "Remote - US", true},
+		{"QA Engineer (Secret Clearance)", "Globex", "Remote - US", true},
+		{"Manualist", "Globex", "Remote - US", false},
+		{"QA Engineer", "Globex", "Pune, India", true},
+		{"QA Engineer", "Globex", "Remote - US; Pune, India", false},
+		{"QA Engineer", "Globex", "Toronto, ON, Canada", true},
+		{"QA Engineer", "Globex", "Vancouver, BC, Canada", false},
+	}
+	for _, tt := range tests {
+		j := model.Job{Title: tt.title, Company: tt.company, Location: tt.location}
+		j.Places, _ = normalize.ParseLocation(j.Location)
+		if got := f.Reject(j); (got != "") != tt.rejected {
+			t.Errorf("Reject(%q, %q, %q) = %q, want rejected=%v", tt.title, tt.company, tt.location, got, tt.rejected)
+		}
+	}
+}
+
+func TestRejectResolvedCompany(t *testing.T) {
+	f := New(Rules{BlockedCompanies: []string{"Acme"}})
+	ids := map[string]int64{"Acme": 7}
+	if err := f.ResolveCompanies(func(name string) (int64, error) { return ids[name], nil }); err != nil {
+		t.Fatal(err)
+	}
+	// "Road Runner Supply" is an alias of Acme, so its jobs carry Acme's id.
+	if got := f.Reject(model.Job{Title: "QA Engineer", Company: "Road Runner Supply", CompanyID: 7}); got != "blocked company: Acme" {
+		t.Errorf("Reject alias = %q", got)
+	}
+	if got := f.Reject(model.Job{Title: "QA Engineer", Company: "Globex", CompanyID: 8}); got != "" {
+		t.Errorf("Reject other company = %q", got)
+	}
+}
+
+func TestRequiredKeywords(t *testing.T) {
+	f := New(Rules{RequiredKeywords: []string{"playwright", "selenium"}})
+	if got := f.Reject(model.Job{Title: "QA Engineer", Description: "We use Playwright daily."}); got != "" {
+		t.Errorf("Reject with keyword = %q", got)
+	}
+	if got := f.Reject(model.Job{Title: "QA Engineer", Description: "Manual regression."}); got != "no required keyword" {
+		t.Errorf("Reject without keyword = %q", got)
+	}
+}
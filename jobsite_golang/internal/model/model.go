@@ -1,16 +1,43 @@
 package model
 
 type Job struct {
-	URL            string `json:"url"`
-	Title          string `json:"title"`
-	Company        string `json:"company"`
-	Location       string `json:"location"`
-	SalaryRaw      string `json:"salary_raw"`
-	SalaryMinUSD   *int   `json:"salary_min_usd"`
-	SalaryMaxUSD   *int   `json:"salary_max_usd"`
-	Source         string `json:"source"`
-	PostedDate     string `json:"posted_date"`
-	DiscoveredDate string `json:"discovered_date"`
-	IsRemoteUS     bool   `json:"is_remote_us"`
-	Tags           string `json:"tags"`
+	URL               string  `json:"url"`
+	Title             string  `json:"title"`
+	Seniority         string  `json:"seniority"`   // see normalize.Seniorities
+	RoleFamily        string  `json:"role_family"` // see normalize.RoleFamilies
+	Company           string  `json:"company"`
+	CompanyID         int64   `json:"company_id,omitempty"` // see store.Company
+	Location          string  `json:"location"`
+	Places            []Place `json:"places"`
+	WorkplaceType     string  `json:"workplace_type"` // remote, hybrid, onsite or "" if unknown
+	SalaryRaw         string  `json:"salary_raw"`
+	SalaryMinUSD      *int    `json:"salary_min_usd"`
+	SalaryMaxUSD      *int    `json:"salary_max_usd"`
+	SalaryCurrency    string  `json:"salary_currency"` // as posted, e.g. "EUR"
+	SalaryPeriod      string  `json:"salary_period"`   // as posted: hour, day, week, month or year
+	Source            string  `json:"source"`
+	PostedDate        string  `json:"posted_date"`
+	DiscoveredDate    string  `json:"discovered_date"`
+	IsRemoteUS        bool    `json:"is_remote_us"`
+	RemoteEligibility string  `json:"remote_eligibility"` // yes, no or unknown; see normalize.ClassifyRemoteUS
+	RemoteEvidence    string  `json:"remote_evidence"`    // phrase the verdict rests on
+	Tags              string  `json:"tags"`
+	Score             int     `json:"score"`             // 0–100 against the profile; see score.Profile
+	ScoreExplanation  string  `json:"score_explanation"` // how Score was reached, part by part
+	EmploymentType    string  `json:"employment_type"`
+	ClosedAt          string  `json:"closed_at,omitempty"`
+	DuplicateOf       string  `json:"duplicate_of,omitempty"` // URL of the primary posting
+
+	// The full description stays out of the JSON exports; they carry Snippet.
+	Description     string `json:"-"` // plain text
+	DescriptionHTML string `json:"-"` // sanitized
+	Snippet         string `json:"description_snippet,omitempty"`
+}
+
+// Place is one location a job can be done from, parsed out of Location.
+type Place struct {
+	City    string `json:"city,omitempty"`
+	State   string `json:"state,omitempty"`   // US state code, e.g. "KS"
+	Country string `json:"country,omitempty"` // ISO 3166-1 alpha-2, e.g. "US"
+	Remote  bool   `json:"remote,omitempty"`  // remote within Country/State, or anywhere if both are empty
 }
@@ -0,0 +1,87 @@
+package normalize
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"jobsite/internal/company"
+)
+
+var (
+	nonWordRe = regexp.MustCompile(`[^a-z0-9+#]+`)
+	// titleNoiseRe is location and workplace wording boards append to titles,
+	// as in "SDET (Remote - US)" or "QA Engineer - Hybrid".
+	titleNoiseRe = regexp.MustCompile(`(?i)\(.*?\)|\[.*?\]|\s[-–—|]\s.*\b(?:remote|hybrid|on-?site|us|usa|united states)\b.*$`)
+)
+
+// titleWords spell out abbreviations so "Sr. QA Eng" matches
+// "Senior QA Engineer".
+var titleWords = map[string]string{
+	"sr": "senior", "snr": "senior", "jr": "junior", "eng": "engineer", "engr": "engineer",
+	"mgr": "manager", "qe": "quality engineer", "swe": "software engineer", "ii": "2", "iii": "3", "iv": "4",
+}
+
+// LocationKey is a stable form of location built from its parsed places,
+// falling back to the lowercased text.
+func LocationKey(location string) string {
+	places, _ := ParseLocation(location)
+	if len(places) == 0 {
+		return strings.Join(strings.Fields(nonWordRe.ReplaceAllString(strings.ToLower(location), " ")), " ")
+	}
+	keys := make([]string, 0, len(places))
+	for _, p := range places {
+		k := strings.ToLower(p.City + "|" + p.State + "|" + p.Country)
+		if p.Remote {
+			k += "|remote"
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ";")
+}
+
+// DedupeKey groups jobs that may be the same posting: same company and
+// same location. Titles are compared within a group with SimilarTitles.
+// A job with no company has no key: unrelated employers' jobs would
+// otherwise fall into one group.
+func DedupeKey(companyName, location string) string {
+	c := company.Key(companyName)
+	if c == "" {
+		return ""
+	}
+	return c + "/" + LocationKey(location)
+}
+
+// TitleTokens is the set of words in title after dropping location noise
+// and expanding abbreviations.
+func TitleTokens(title string) map[string]bool {
+	t := titleNoiseRe.ReplaceAllString(title, " ")
+	out := map[string]bool{}
+	for _, w := range strings.Fields(nonWordRe.ReplaceAllString(strings.ToLower(t), " ")) {
+		if full, ok := titleWords[w]; ok {
+			for _, f := range strings.Fields(full) {
+				out[f] = true
+			}
+			continue
+		}
+		out[w] = true
+	}
+	return out
+}
+
+// SimilarTitles reports whether two titles name the same role: their word
+// sets overlap by at least 80% (Jaccard).
+func SimilarTitles(a, b string) bool {
+	ta, tb := TitleTokens(a), TitleTokens(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return false
+	}
+	both := 0
+	for w := range ta {
+		if tb[w] {
+			both++
+		}
+	}
+	return float64(both)/float64(len(ta)+len(tb)-both) >= 0.8
+}
@@ -0,0 +1,34 @@
+package normalize
+
+import "testing"
+
+func TestDedupeKey(t *testing.T) {
+	if k := DedupeKey("", "Remote - US"); k != "" {
+		t.Errorf("DedupeKey with no company = %q, want empty", k)
+	}
+	if a, b := DedupeKey("Acme, Inc.", "Remote - US"), DedupeKey("acme", "Remote (USA)"); a == "" || a != b {
+		t.Errorf("DedupeKey mismatch: %q vs %q", a, b)
+	}
+	if a, b := DedupeKey("Acme", "Austin, TX"), DedupeKey("Acme", "Boston, MA"); a == b {
+		t.Errorf("DedupeKey(%q) equals other location", a)
+	}
+}
+
+func TestSimilarTitles(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"Senior QA Engineer", "Sr. QA Engineer (Remote - US)", true},
+		{"Senior QA Engineer", "Sr QA Eng", true},
+		{"SDET II", "SDET 2 - Remote", true},
+		{"QA Engineer", "Data Engineer", false},
+		{"Senior QA Engineer", "QA Manager", false},
+		{"", "QA Engineer", false},
+	}
+	for _, tt := range tests {
+		if got := SimilarTitles(tt.a, tt.b); got != tt.want {
+			t.Errorf("SimilarTitles(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package normalize
+
+import (
+	"regexp"
+	"strings"
+
+	"jobsite/internal/model"
+)
+
+// Workplace types stored in model.Job.WorkplaceType.
+const (
+	WorkplaceRemote = "remote"
+	WorkplaceHybrid = "hybrid"
+	WorkplaceOnsite = "onsite"
+)
+
+var usStates = map[string]string{
+	"alabama": "AL", "alaska": "AK", "arizona": "AZ", "arkansas": "AR", "california": "CA",
+	"colorado": "CO", "connecticut": "CT", "delaware": "DE", "district of columbia": "DC",
+	"florida": "FL", "georgia": "GA", "hawaii": "HI", "idaho": "ID", "illinois": "IL",
+	"indiana": "IN", "iowa": "IA", "kansas": "KS", "kentucky": "KY", "louisiana": "LA",
+	"maine": "ME", "maryland": "MD", "massachusetts": "MA", "michigan": "MI", "minnesota": "MN",
+	"mississippi": "MS", "missouri": "MO", "montana": "MT", "nebraska": "NE", "nevada": "NV",
+	"new hampshire": "NH", "new jersey": "NJ", "new mexico": "NM", "new york": "NY",
+	"north carolina": "NC", "north dakota": "ND", "ohio": "OH", "oklahoma": "OK", "oregon": "OR",
+	"pennsylvania": "PA", "rhode island": "RI", "south carolina": "SC", "south dakota": "SD",
+	"tennessee": "TN", "texas": "TX", "utah": "UT", "vermont": "VT", "virginia": "VA",
+	"washington": "WA", "west virginia": "WV", "wisconsin": "WI", "wyoming": "WY",
+	"puerto rico": "PR",
+}
+
+// usStateCodes is the reverse of usStates.
+var usStateCodes = func() map[string]bool {
+	m := map[string]bool{}
+	for _, c := range usStates {
+		m[c] = true
+	}
+	return m
+}()
+
+var countries = map[string]string{
+	"us": "US", "usa": "US", "u.s.": "US", "u.s.a.": "US", "united states": "US",
+	"united states of america": "US", "america": "US",
+	"canada": "CA", "mexico": "MX", "brazil": "BR", "argentina": "AR", "colombia": "CO",
+	"uk": "GB", "u.k.": "GB", "united kingdom": "GB", "great britain": "GB", "england": "GB",
+	"scotland": "GB", "ireland": "IE", "germany": "DE", "france": "FR", "spain": "ES",
+	"portugal": "PT", "netherlands": "NL", "belgium": "BE", "switzerland": "CH", "austria": "AT",
+	"poland": "PL", "sweden": "SE", "norway": "NO", "denmark": "DK", "finland": "FI",
+	"italy": "IT", "romania": "RO", "ukraine": "UA", "israel": "IL", "india": "IN",
+	"singapore": "SG", "japan": "JP", "philippines": "PH", "australia": "AU", "new zealand": "NZ",
+	"emea": "", "europe": "", "latam": "", "latin america": "", "south america": "", "central america": "", "apac": "", "north america": "",
+}
+
+var (
+	// placeSepRe splits multi-location strings into one part per place.
+	placeSepRe = regexp.MustCompile(`\s*(?:;|\||•|\n|\s/\s|\bor\b)\s*`)
+	remoteRe   = regexp.MustCompile(`(?i)\b(?:remote|anywhere|work from home|wfh|telecommute|distributed)\b`)
+	hybridRe   = regexp.MustCompile(`(?i)\bhybrid\b`)
+	onsiteRe   = regexp.MustCompile(`(?i)\b(?:on-?site|in[- ]office|in[- ]person)\b`)
+	// workplaceNoiseRe is stripped from a part once the workplace type is known.
+	workplaceNoiseRe = regexp.MustCompile(`(?i)\b(?:remote|anywhere|work from home|wfh|telecommute|distributed|hybrid|on-?site|in[- ]office|in[- ]person|fully|first|friendly|only|based|100%)\b|[()\[\]]`)
+	// icimsRe matches iCIMS's "US-KS-Wichita".
+	icimsRe = regexp.MustCompile(`^([A-Za-z]{2})-([A-Za-z]{2})-(.+)$`)
+	zipRe   = regexp.MustCompile(`\s+\d{5}(?:-\d{4})?$`)
+	vagueRe = regexp.MustCompile(`(?i)^(?:multiple locations|various locations|various|tbd|n/a|locations?)$`)
+)
+
+// ParseLocation splits a free-text location such as
+// "Remote - US; New York, NY; Wichita, KS" into places and works out the
+// workplace type: hybrid if any part says so, remote if any part is remote,
+// onsite if it names places but never remote work, else "".
+func ParseLocation(s string) ([]model.Place, string) {
+	var places []model.Place
+	seen := map[model.Place]bool{}
+	add := func(p model.Place) {
+		if !seen[p] {
+			seen[p] = true
+			places = append(places, p)
+		}
+	}
+	for _, part := range placeSepRe.Split(s, -1) {
+		remote := remoteRe.MatchString(part)
+		for _, p := range parsePlaces(workplaceNoiseRe.ReplaceAllString(part, " ")) {
+			p.Remote = remote
+			add(p)
+		}
+		if remote && !placesIn(part) {
+			add(model.Place{Remote: true})
+		}
+	}
+
+	workplace := ""
+	switch {
+	case hybridRe.MatchString(s):
+		workplace = WorkplaceHybrid
+	case remoteRe.MatchString(s):
+		workplace = WorkplaceRemote
+	case onsiteRe.MatchString(s) || len(places) > 0:
+		workplace = WorkplaceOnsite
+	}
+	return places, workplace
+}
+
+// placesIn reports whether part names anything besides the workplace type.
+func placesIn(part string) bool {
+	return len(parsePlaces(workplaceNoiseRe.ReplaceAllString(part, " "))) > 0
+}
+
+// parsePlaces reads the comma-separated parts of one location. A US state
+// code followed by more than a country starts a new place, so
+// "New York, NY, Austin, TX" yields two.
+func parsePlaces(s string) []model.Place {
+	s = strings.Trim(strings.Join(strings.Fields(s), " "), " -–—:,/")
+	if s == "" || vagueRe.MatchString(s) {
+		return nil
+	}
+	if m := icimsRe.FindStringSubmatch(s); m != nil && usStateCodes[strings.ToUpper(m[2])] {
+		return []model.Place{{City: strings.TrimSpace(m[3]), State: strings.ToUpper(m[2]), Country: countryCode(m[1])}}
+	}
+
+	var parts []string
+	for _, p := range strings.Split(s, ",") {
+		p = strings.Trim(zipRe.ReplaceAllString(strings.TrimSpace(p), ""), " -–—:")
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	var out []model.Place
+	start := 0
+	for i, p := range parts {
+		if i == len(parts)-1 {
+			out = append(out, placeFrom(parts[start:]))
+			break
+		}
+		if usStateCodes[p] && i > start {
+			if _, isCountry := countries[strings.ToLower(parts[i+1])]; !isCountry {
+				out = append(out, placeFrom(parts[start:i+1]))
+				start = i + 1
+			}
+		}
+	}
+	return out
+}
+
+// placeFrom reads [city, ..., state, country] from the end: a trailing
+// country, then a US state, and the first remaining part as the city.
+func placeFrom(parts []string) model.Place {
+	var p model.Place
+	if n := len(parts); n > 0 {
+		if c, ok := countries[strings.ToLower(parts[n-1])]; ok {
+			p.Country = c
+			parts = parts[:n-1]
+		}
+	}
+	if n := len(parts); n > 0 && (p.Country == "" || p.Country == "US") {
+		if st := stateCode(parts[n-1]); st != "" && (n > 1 || !cityNamedLikeState[strings.ToLower(parts[0])]) {
+			p.State = st
+			parts = parts[:n-1]
+		}
+	}
+	if len(parts) > 0 {
+		p.City = parts[0]
+	}
+	if p.State != "" && p.Country == "" {
+		p.Country = "US"
+	}
+	return p
+}
+
+// cityNamedLikeState are state names that, on their own, usually mean the
+// city.
+var cityNamedLikeState = map[string]bool{"new york": true, "washington": true}
+
+func stateCode(s string) string {
+	if up := strings.ToUpper(s); len(s) == 2 && usStateCodes[up] {
+		return up
+	}
+	return usStates[strings.ToLower(s)]
+}
+
+func countryCode(s string) string {
+	if c, ok := countries[strings.ToLower(s)]; ok && c != "" {
+		return c
+	}
+	return strings.ToUpper(s)
+}
@@ -0,0 +1,39 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+
+	"jobsite/internal/model"
+)
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		in        string
+		places    []model.Place
+		workplace string
+	}{
+		{"Remote - US; New York, NY; Wichita, KS", []model.Place{
+			{Country: "US", Remote: true}, {City: "New York", State: "NY", Country: "US"}, {City: "Wichita", State: "KS", Country: "US"},
+		}, WorkplaceRemote},
+		{"New York, NY, Austin, TX", []model.Place{
+			{City: "New York", State: "NY", Country: "US"}, {City: "Austin", State: "TX", Country: "US"},
+		}, WorkplaceOnsite},
+		{"US-KS-Wichita", []model.Place{{City: "Wichita", State: "KS", Country: "US"}}, WorkplaceOnsite},
+		{"San Francisco, California 94105", []model.Place{{City: "San Francisco", State: "CA", Country: "US"}}, WorkplaceOnsite},
+		{"Hybrid - Boston, MA", []model.Place{{City: "Boston", State: "MA", Country: "US"}}, WorkplaceHybrid},
+		{"Toronto, ON, Canada", []model.Place{{City: "Toronto", State: "", Country: "CA"}}, WorkplaceOnsite},
+		{"London, United Kingdom", []model.Place{{City: "London", Country: "GB"}}, WorkplaceOnsite},
+		{"New York", []model.Place{{City: "New York"}}, WorkplaceOnsite},
+		{"Remote", []model.Place{{Remote: true}}, WorkplaceRemote},
+		{"Latin America - Remote", []model.Place{{Remote: true}}, WorkplaceRemote},
+		{"Multiple Locations", nil, ""},
+		{"", nil, ""},
+	}
+	for _, tt := range tests {
+		places, workplace := ParseLocation(tt.in)
+		if !reflect.DeepEqual(places, tt.places) || workplace != tt.workplace {
+			t.Errorf("ParseLocation(%q) = %+v, %q; want %+v, %q", tt.in, places, workplace, tt.places, tt.workplace)
+		}
+	}
+}
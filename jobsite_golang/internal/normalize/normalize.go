@@ -3,65 +3,89 @@ package normalize
 import (
 	"net/url"
 	"regexp"
-	"strconv"
 	"strings"
 )
 
+// trackingParams are dropped from every URL; utm_* is handled by prefix.
+var trackingParams = map[string]bool{
+	"gh_src": true, "lever-source": true, "lever-origin": true, "source": true, "src": true,
+	"ref": true, "referrer": true, "gclid": true, "fbclid": true, "mc_cid": true, "mc_eid": true,
+}
+
+var (
+	// greenhouseJobRe matches /<board>/jobs/<id> on boards.greenhouse.io and
+	// job-boards.greenhouse.io (including the eu. variants).
+	greenhouseJobRe = regexp.MustCompile(`^/([^/]+)/jobs/(\d+)`)
+	// atsJobRe matches /<company>/<posting id> on Lever and Ashby; anything
+	// after the id (/apply, /application) is the same posting.
+	atsJobRe = regexp.MustCompile(`^/([^/]+)/([0-9a-fA-F-]{16,})`)
+	// workableJobRe matches /<company>/j/<shortcode> on apply.workable.com.
+	workableJobRe = regexp.MustCompile(`^/([^/]+)/j/([0-9A-Za-z]+)`)
+	// smartRecruitersJobRe matches /<company>/<numeric id>-<slug>.
+	smartRecruitersJobRe = regexp.MustCompile(`^/([^/]+)/(\d+)`)
+)
+
+// CanonicalURL reduces a posting URL to one form per job: tracking
+// parameters and fragments go, hosts are lowercased, and postings on the
+// ATSs we know are rewritten to the ATS's own job URL, so
+// job-boards.greenhouse.io/acme/jobs/123?gh_jid=123 and
+// boards.greenhouse.io/acme/jobs/123 are the same row. Careers pages that
+// embed Greenhouse keep only gh_jid; the same job on the board is linked to
+// them as a duplicate by company, location and title.
 func CanonicalURL(raw string) string {
-	u, err := url.Parse(raw)
-	if err != nil {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
 		return raw
 	}
 	u.Fragment = ""
-	q := u.Query()
-	for k := range q {
-		lk := strings.ToLower(k)
-		if strings.HasPrefix(lk, "utm_") || strings.HasPrefix(lk, "gh_src") || strings.HasPrefix(lk, "lever-source") {
-			q.Del(k)
-		}
-	}
-	u.RawQuery = q.Encode()
 	u.Host = strings.ToLower(u.Host)
-	return u.String()
-}
-
-var moneyRe = regexp.MustCompile(`\d{2,3}(?:,\d{3})?`)
-var kRe = regexp.MustCompile(`(\d{2,3})\s*k`)
+	host := strings.TrimPrefix(u.Hostname(), "www.")
+	q := u.Query()
 
-func SalaryToRangeUSD(s string) (minPtr, maxPtr *int) {
-	if s == "" {
-		return nil, nil
-	}
-	if k := kRe.FindAllStringSubmatch(strings.ToLower(s), -1); len(k) >= 1 {
-		nums := []int{}
-		for _, m := range k {
-			n, _ := strconv.Atoi(m[1])
-			nums = append(nums, n*1000)
+	switch {
+	case strings.HasSuffix(host, "greenhouse.io"):
+		// EU boards only exist on the EU host.
+		base := "https://boards.greenhouse.io/"
+		if strings.HasSuffix(host, ".eu.greenhouse.io") {
+			base = "https://job-boards.eu.greenhouse.io/"
 		}
-		if len(nums) == 1 {
-			nums = append(nums, nums[0])
+		if m := greenhouseJobRe.FindStringSubmatch(u.Path); m != nil {
+			return base + strings.ToLower(m[1]) + "/jobs/" + m[2]
 		}
-		min, max := nums[0], nums[len(nums)-1]
-		return &min, &max
-	}
-	if m := moneyRe.FindAllString(s, -1); len(m) >= 1 {
-		nums := []int{}
-		for _, a := range m {
-			v, _ := strconv.Atoi(strings.ReplaceAll(a, ",", ""))
-			nums = append(nums, v)
+		if board, id := q.Get("for"), q.Get("token"); board != "" && id != "" { // embed/job_app
+			return base + strings.ToLower(board) + "/jobs/" + id
+		}
+	case q.Get("gh_jid") != "":
+		// A careers page embedding a Greenhouse posting: the job id is the
+		// only query parameter that picks the posting.
+		u.Host = host
+		u.Path = strings.TrimSuffix(u.Path, "/")
+		u.RawQuery = url.Values{"gh_jid": {q.Get("gh_jid")}}.Encode()
+		return u.String()
+	case host == "jobs.lever.co" || host == "jobs.eu.lever.co" || host == "jobs.ashbyhq.com":
+		if m := atsJobRe.FindStringSubmatch(u.Path); m != nil {
+			return "https://" + host + "/" + strings.ToLower(m[1]) + "/" + strings.ToLower(m[2])
 		}
-		if len(nums) == 1 {
-			nums = append(nums, nums[0])
+	case host == "apply.workable.com":
+		if m := workableJobRe.FindStringSubmatch(u.Path); m != nil {
+			return "https://apply.workable.com/" + strings.ToLower(m[1]) + "/j/" + strings.ToUpper(m[2]) + "/"
 		}
-		min, max := nums[0], nums[len(nums)-1]
-		return &min, &max
+	case host == "jobs.smartrecruiters.com":
+		if m := smartRecruitersJobRe.FindStringSubmatch(u.Path); m != nil {
+			return "https://jobs.smartrecruiters.com/" + m[1] + "/" + m[2]
+		}
+	case strings.HasSuffix(host, ".myworkdayjobs.com"):
+		u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/apply")
+		u.RawQuery = ""
+		return u.String()
 	}
-	return nil, nil
-}
 
-func IsRemoteUS(loc string, pageText string) bool {
-	l := strings.ToLower(loc + " " + pageText)
-	return strings.Contains(l, "remote - us") ||
-		strings.Contains(l, "remote (us)") ||
-		(strings.Contains(l, "remote") && (strings.Contains(l, "united states") || strings.Contains(l, " us")))
+	for k := range q {
+		lk := strings.ToLower(k)
+		if strings.HasPrefix(lk, "utm_") || trackingParams[lk] {
+			q.Del(k)
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
 }
@@ -0,0 +1,26 @@
+package normalize
+
+import "testing"
+
+func TestCanonicalURL(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"https://boards.greenhouse.io/acme/jobs/123", "https://boards.greenhouse.io/acme/jobs/123"},
+		{"https://job-boards.greenhouse.io/Acme/jobs/123?gh_jid=123#app", "https://boards.greenhouse.io/acme/jobs/123"},
+		{"https://boards.greenhouse.io/embed/job_app?for=acme&token=123", "https://boards.greenhouse.io/acme/jobs/123"},
+		{"https://job-boards.eu.greenhouse.io/acme/jobs/456", "https://job-boards.eu.greenhouse.io/acme/jobs/456"},
+		{"https://boards.eu.greenhouse.io/acme/jobs/456?gh_src=x", "https://job-boards.eu.greenhouse.io/acme/jobs/456"},
+		{"https://www.acme.com/careers/?gh_jid=789&gh_src=li&utm_source=x", "https://acme.com/careers?gh_jid=789"},
+		{"https://acme.com/careers?utm_medium=y&gh_jid=789", "https://acme.com/careers?gh_jid=789"},
+		{"https://jobs.lever.co/Acme/0A1B2C3D-4E5F-6789-ABCD-EF0123456789/apply?lever-source=li", "https://jobs.lever.co/acme/0a1b2c3d-4e5f-6789-abcd-ef0123456789"},
+		{"https://jobs.ashbyhq.com/acme/0a1b2c3d-4e5f-6789-abcd-ef0123456789/application", "https://jobs.ashbyhq.com/acme/0a1b2c3d-4e5f-6789-abcd-ef0123456789"},
+		{"https://apply.workable.com/acme/j/ab12cd/apply", "https://apply.workable.com/acme/j/AB12CD/"},
+		{"https://acme.wd5.myworkdayjobs.com/en-US/careers/job/QA-Engineer_R123/apply?source=li", "https://acme.wd5.myworkdayjobs.com/en-US/careers/job/QA-Engineer_R123"},
+		{"https://Example.com/jobs/1?utm_source=x&id=2#top", "https://example.com/jobs/1?id=2"},
+		{"not a url", "not a url"},
+	}
+	for _, tt := range tests {
+		if got := CanonicalURL(tt.in); got != tt.want {
+			t.Errorf("CanonicalURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package normalize
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"jobsite/internal/model"
+)
+
+// Remote eligibility verdicts stored in model.Job.RemoteEligibility.
+const (
+	RemoteYes     = "yes"
+	RemoteNo      = "no"
+	RemoteUnknown = "unknown"
+)
+
+// SignalEvidence opens the evidence of every verdict decided by structured
+// signals rather than text: schema.org jobLocationType TELECOMMUTE or an ATS
+// API's remote flag, with any applicant locations. Stored text alone can't
+// reproduce such a verdict, so store.Renormalize keeps any whose evidence
+// starts with this.
+const SignalEvidence = "TELECOMMUTE"
+
+// RemoteVerdict says whether a job can be done remotely from the US, and
+// the phrase that decided it.
+type RemoteVerdict struct {
+	Eligible string
+	Evidence string
+}
+
+// SetOn records v on j, keeping IsRemoteUS in step.
+func (v RemoteVerdict) SetOn(j *model.Job) {
+	j.RemoteEligibility, j.RemoteEvidence = v.Eligible, v.Evidence
+	j.IsRemoteUS = v.Eligible == RemoteYes
+}
+
+// elsewhere matches a place other than the US, built from the countries
+// table.
+var elsewhere = func() string {
+	var names []string
+	for name, code := range countries {
+		if code != "US" && name != "north america" {
+			names = append(names, regexp.QuoteMeta(name))
+		}
+	}
+	// Longest first, so "united kingdom" wins over "uk".
+	sort.Slice(names, func(i, k int) bool { return len(names[i]) > len(names[k]) })
+	return `(?:` + strings.Join(names, "|") + `)`
+}()
+
+// usName is an unambiguous name for the US. Bare "us" and "america" are
+// not: "contact us", "about us, remote-friendly", "Latin America".
+const usName = `(?:u\.s\.a?\.?|usa|united states(?: of america)?)`
+
+// usBare is "US" or "America", trusted only when it opens a location part
+// right next to "remote": "Remote - US", "US-Remote", "America (Remote)".
+const usBare = `(?:^|[;|•\n])\s*(?:remote\s*[-–—:,(/|]*\s*(?:us|america)\b|(?:us|america)\s*[-–—:,(/]*\s*remote\b)`
+
+var (
+	// remoteElsewhereRe: "Remote (Canada only)", "Remote - UK", "EMEA remote".
+	remoteElsewhereRe = regexp.MustCompile(`(?i)\bremote\s*[-–—:,(/|]*\s*(?:in\s+|within\s+)?(?:the\s+)?` + elsewhere + `\b(?:\s+only)?|\b` + elsewhere + `\s*[-–—:,(/]*\s*(?:based\s+)?remote\b`)
+	// remoteUSRe: "Remote - US", "Remote (USA)", "US-Remote", "remote within the United States".
+	remoteUSRe = regexp.MustCompile(`(?i)\bremote\s*[-–—:,(/|]*\s*(?:in\s+|within\s+|across\s+|anywhere in\s+)?(?:the\s+)?` + usName + `\b|\b` + usName + `\s*[-–—:,(/]*\s*(?:based\s+)?remote\b|\banywhere in the (?:us\b|` + usName + `\b)|` + usBare)
+	// residencyRe captures where a candidate must live: "must reside in EST",
+	// "must be located in the United States".
+	residencyRe = regexp.MustCompile(`(?i)\b(?:must|required to|need to|should)\s+(?:reside|live|be located|be based)\s+(?:in|within|from)\s+(?:the\s+|one of the following\s+)?([^.;:\n]{2,40})`)
+	usWideRe    = regexp.MustCompile(`(?i)^(?:us\b|` + usName + `\b)|^(?:any|all)\s+(?:us|u\.s\.)?\s*states?\b|^(?:contiguous|continental|lower 48)`)
+	// hybridDaysRe is hybrid wording safe to trust in prose, where a bare
+	// "hybrid" may be a skill ("hybrid apps").
+	hybridDaysRe = regexp.MustCompile(`(?i)\bhybrid\s*[-–—:,(]*\s*(?:\d|one|two|three|four)\s*(?:\+\s*)?days?|\b(?:\d|one|two|three|four)\s*(?:\+\s*)?days?\s*(?:a|per|each)?\s*(?:week\s*)?(?:in|at|on)[- ](?:the\s+|our\s+)?(?:office|site)\b|\bhybrid\s+(?:role|position|schedule|work|model|arrangement)\b`)
+	onsiteOnlyRe = regexp.MustCompile(`(?i)\b(?:on-?site|in[- ]office|in[- ]person)\s+(?:only|required|role|position)\b|\b(?:this is not a remote|not remote|no remote)\b`)
+	remoteWordRe = regexp.MustCompile(`(?i)\b(?:remote|telecommute|work from home|wfh|distributed)\b`)
+)
+
+// ClassifyRemoteUS decides whether a job can be worked remotely from the US
+// from its location, its description text and any schema.org signals
+// (jobLocationType TELECOMMUTE and applicantLocationRequirements).
+// Restrictions win over generic remote wording: a job that is hybrid,
+// remote only outside the US, or limited to some time zone or states is
+// "no". A job that says remote without saying where is "unknown".
+func ClassifyRemoteUS(location, text string, telecommute bool, applicantLocations []string) RemoteVerdict {
+	for i, s := range []string{location, text} {
+		if v, ok := restricted(s, i == 0); ok {
+			return v
+		}
+	}
+	if telecommute {
+		for _, l := range applicantLocations {
+			if countryCode(strings.TrimSpace(l)) == "US" {
+				return RemoteVerdict{RemoteYes, SignalEvidence + ", applicant location " + strings.TrimSpace(l)}
+			}
+		}
+		if len(applicantLocations) > 0 {
+			return RemoteVerdict{RemoteNo, SignalEvidence + ", applicant location " + strings.Join(applicantLocations, ", ")}
+		}
+	}
+	for _, s := range []string{location, text} {
+		if loc := remoteUSRe.FindStringIndex(s); loc != nil {
+			return RemoteVerdict{RemoteYes, evidence(s, loc)}
+		}
+		if m := residencyRe.FindStringSubmatchIndex(s); m != nil && usWideRe.MatchString(strings.TrimSpace(s[m[2]:m[3]])) {
+			return RemoteVerdict{RemoteYes, evidence(s, m[:2])}
+		}
+	}
+	if telecommute {
+		return RemoteVerdict{RemoteUnknown, SignalEvidence}
+	}
+	for _, s := range []string{location, text} {
+		if loc := remoteWordRe.FindStringIndex(s); loc != nil {
+			return RemoteVerdict{RemoteUnknown, evidence(s, loc)}
+		}
+	}
+	return RemoteVerdict{RemoteUnknown, ""}
+}
+
+// restricted looks for wording that rules out remote work from anywhere in
+// the US. A location that says hybrid and never remote counts; prose needs
+// the stricter phrasings.
+func restricted(s string, isLocation bool) (RemoteVerdict, bool) {
+	if s == "" {
+		return RemoteVerdict{}, false
+	}
+	if isLocation && !remoteWordRe.MatchString(s) {
+		if loc := hybridRe.FindStringIndex(s); loc != nil {
+			return RemoteVerdict{RemoteNo, evidence(s, loc)}, true
+		}
+	}
+	if loc := hybridDaysRe.FindStringIndex(s); loc != nil {
+		return RemoteVerdict{RemoteNo, evidence(s, loc)}, true
+	}
+	if loc := onsiteOnlyRe.FindStringIndex(s); loc != nil {
+		return RemoteVerdict{RemoteNo, evidence(s, loc)}, true
+	}
+	if loc := remoteElsewhereRe.FindStringIndex(s); loc != nil && !remoteUSRe.MatchString(s) {
+		return RemoteVerdict{RemoteNo, evidence(s, loc)}, true
+	}
+	for _, m := range residencyRe.FindAllStringSubmatchIndex(s, -1) {
+		if !usWideRe.MatchString(strings.TrimSpace(s[m[2]:m[3]])) {
+			return RemoteVerdict{RemoteNo, evidence(s, m[:2])}, true
+		}
+	}
+	return RemoteVerdict{}, false
+}
+
+// evidence returns the match at loc with a few words either side, so the
+// stored snippet reads on its own.
+func evidence(s string, loc []int) string {
+	const pad = 30
+	start, end := loc[0], loc[1]
+	if start > pad {
+		start = start - pad
+		if i := strings.IndexAny(s[start:loc[0]], " \n"); i >= 0 {
+			start += i + 1
+		}
+	} else {
+		start = 0
+	}
+	if end+pad < len(s) {
+		if i := strings.LastIndexAny(s[loc[1]:end+pad], " \n"); i >= 0 {
+			end += i
+		}
+	} else {
+		end = len(s)
+	}
+	return strings.Join(strings.Fields(s[start:end]), " ")
+}
@@ -0,0 +1,67 @@
+package normalize
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClassifyRemoteUS(t *testing.T) {
+	tests := []struct {
+		location, text string
+		want           string
+	}{
+		{"Remote - US", "", RemoteYes},
+		{"Remote (USA)", "", RemoteYes},
+		{"US-Remote", "", RemoteYes},
+		{"New York, NY; Remote - US", "", RemoteYes},
+		{"Remote", "This role is remote within the United States.", RemoteYes},
+		{"Remote", "Candidates must be located in the United States.", RemoteYes},
+		{"Remote", "", RemoteUnknown},
+		{"Remote", "Learn more about us, remote-friendly culture", RemoteUnknown},
+		{"Contact us / Remote", "", RemoteUnknown},
+		{"Remote", "Contact us / Remote", RemoteUnknown},
+		{"Latin America - Remote", "", RemoteNo},
+		{"South America (Remote)", "", RemoteNo},
+		{"Remote - Central America", "", RemoteNo},
+		{"Remote (Canada only)", "", RemoteNo},
+		{"Remote - UK", "", RemoteNo},
+		{"Remote", "You must reside in EST.", RemoteNo},
+		{"Austin, TX", "This is a hybrid role, 3 days in office.", RemoteNo},
+		{"Hybrid - Boston, MA", "", RemoteNo},
+		{"Remote", "Hybrid 3 days a week in our Denver office.", RemoteNo},
+		{"Remote", "Remote role. About us | Careers | Contact us", RemoteUnknown},
+		{"Austin, TX", "", RemoteUnknown},
+	}
+	for _, tt := range tests {
+		got := ClassifyRemoteUS(tt.location, tt.text, false, nil)
+		if got.Eligible != tt.want {
+			t.Errorf("ClassifyRemoteUS(%q, %q) = %q (%q), want %q", tt.location, tt.text, got.Eligible, got.Evidence, tt.want)
+		}
+	}
+}
+
+func TestClassifyRemoteUSSchema(t *testing.T) {
+	tests := []struct {
+		locations []string
+		want      string
+	}{
+		{[]string{"United States"}, RemoteYes},
+		{[]string{"USA"}, RemoteYes},
+		{[]string{"Canada"}, RemoteNo},
+		{nil, RemoteUnknown},
+	}
+	for _, tt := range tests {
+		got := ClassifyRemoteUS("", "", true, tt.locations)
+		if got.Eligible != tt.want {
+			t.Errorf("ClassifyRemoteUS(TELECOMMUTE, %q) = %q, want %q", tt.locations, got.Eligible, tt.want)
+		}
+		// Renormalize keeps verdicts by this prefix.
+		if !strings.HasPrefix(got.Evidence, SignalEvidence) {
+			t.Errorf("ClassifyRemoteUS(TELECOMMUTE, %q) evidence %q lacks %q", tt.locations, got.Evidence, SignalEvidence)
+		}
+	}
+	// Text that decides on its own is not signal evidence.
+	if got := ClassifyRemoteUS("Remote - US", "", true, nil); strings.HasPrefix(got.Evidence, SignalEvidence) {
+		t.Errorf("text verdict recorded as signal evidence: %q", got.Evidence)
+	}
+}
@@ -0,0 +1,226 @@
+package normalize
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Salary is a pay range as posted: Min and Max in Currency per Period.
+type Salary struct {
+	Min      float64
+	Max      float64
+	Currency string // ISO 4217, e.g. "USD"
+	Period   string // "hour", "day", "week", "month" or "year"
+}
+
+// FXRates converts currencies to USD: the USD value of one unit of each
+// currency. Main replaces entries from FX_RATES; the defaults are rough and
+// only meant to put foreign salaries in the right band.
+var FXRates = map[string]float64{
+	"USD": 1,
+	"CAD": 0.73,
+	"EUR": 1.08,
+	"GBP": 1.27,
+	"AUD": 0.66,
+	"NZD": 0.60,
+	"CHF": 1.12,
+	"SEK": 0.095,
+	"NOK": 0.094,
+	"DKK": 0.145,
+	"PLN": 0.25,
+	"INR": 0.012,
+	"JPY": 0.0067,
+	"MXN": 0.055,
+	"BRL": 0.18,
+	"SGD": 0.74,
+	"ILS": 0.27,
+}
+
+// ParseFXRates reads "EUR=1.08,GBP=1.27" into a rate table.
+func ParseFXRates(s string) (map[string]float64, error) {
+	out := map[string]float64{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		code, val, ok := strings.Cut(pair, "=")
+		rate, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		if !ok || err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid FX rate %q (want e.g. EUR=1.08)", pair)
+		}
+		out[strings.ToUpper(strings.TrimSpace(code))] = rate
+	}
+	return out, nil
+}
+
+// periodsPerYear annualizes a salary, assuming a 40-hour, 5-day week.
+var periodsPerYear = map[string]float64{
+	"hour":  2080,
+	"day":   260,
+	"week":  52,
+	"month": 12,
+	"year":  1,
+}
+
+// periodWords name a pay period; the one appearing earliest in the text wins,
+// so "$150k per year, 40 hours per week" stays yearly.
+var periodWords = []struct {
+	period string
+	words  []string
+}{
+	{"hour", []string{"per hour", "an hour", "/hour", "/ hour", "/hr", "/ hr", "hourly", "p/h"}},
+	{"day", []string{"per day", "a day", "/day", "/ day", "daily"}},
+	{"week", []string{"per week", "a week", "/week", "/ week", "/wk", "weekly"}},
+	{"month", []string{"per month", "a month", "/month", "/ month", "/mo", "monthly", "p.m."}},
+	{"year", []string{"per year", "a year", "/year", "/ year", "/yr", "per annum", "annually", "annual", "p.a.", "yearly"}},
+}
+
+// currencyPrefixes are checked in order, so "US$" and "C$" win over "$".
+var currencyPrefixes = []struct {
+	mark, code string
+}{
+	{"us$", "USD"}, {"c$", "CAD"}, {"ca$", "CAD"}, {"cad$", "CAD"}, {"a$", "AUD"}, {"au$", "AUD"},
+	{"nz$", "NZD"}, {"s$", "SGD"}, {"r$", "BRL"}, {"mx$", "MXN"},
+	{"€", "EUR"}, {"£", "GBP"}, {"₹", "INR"}, {"¥", "JPY"}, {"₪", "ILS"}, {"$", "USD"},
+}
+
+var (
+	currencyCodeRe = regexp.MustCompile(`\b(usd|cad|eur|gbp|aud|nzd|chf|sek|nok|dkk|pln|inr|jpy|mxn|brl|sgd|ils)\b`)
+	amountRe       = regexp.MustCompile(`(\d[\d,.]*\d|\d)(\s*[km]\b)?`)
+	rangeSepRe     = regexp.MustCompile(`^(?:-|–|—|−|to|and)$`)
+	gapNoiseRe     = regexp.MustCompile(`[$€£₹¥₪]|\b(?:usd|cad|eur|gbp|aud|nzd|chf|sek|nok|dkk|pln|inr|jpy|mxn|brl|sgd|ils|us|ca|au|nz|mx|c|a|s|r)\b|\s`)
+)
+
+// ParseSalary reads a pay range such as "$55/hr", "€90,000",
+// "CAD 120K–140K", "$8,500/month" or "$150,000 - $180,000 USD + equity".
+// Text without a currency is taken as USD; text without a period is taken as
+// hourly, monthly or yearly by the size of the figures.
+func ParseSalary(s string) (Salary, bool) {
+	lower := strings.ToLower(s)
+	m := amountRe.FindAllStringSubmatchIndex(lower, -1)
+	if len(m) == 0 {
+		return Salary{}, false
+	}
+	min, minK, ok := parseAmount(lower, m[0])
+	if !ok || min <= 0 {
+		return Salary{}, false
+	}
+	max, maxK := min, minK
+	if len(m) > 1 {
+		gap := gapNoiseRe.ReplaceAllString(lower[m[0][1]:m[1][0]], "")
+		if rangeSepRe.MatchString(gap) {
+			if v, k, ok := parseAmount(lower, m[1]); ok && v > 0 {
+				max, maxK = v, k
+			}
+		}
+	}
+	// "120–140K" puts the suffix on the upper figure only.
+	if maxK > 1 && minK == 1 && min < max {
+		min *= maxK
+	}
+	min, max = min*minK, max*maxK
+	if max < min {
+		min, max = max, min
+	}
+	return NewSalary(min, max, currencyOf(lower), periodOf(lower)), true
+}
+
+// NewSalary builds a Salary, defaulting the currency to USD and inferring a
+// missing period from the size of max. period may be a schema.org unitText
+// such as "HOUR".
+func NewSalary(min, max float64, currency, period string) Salary {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		currency = "USD"
+	}
+	period = strings.ToLower(strings.TrimSpace(period))
+	if _, ok := periodsPerYear[period]; !ok {
+		switch {
+		case max < 300:
+			period = "hour"
+		case max < 20000:
+			period = "month"
+		default:
+			period = "year"
+		}
+	}
+	return Salary{Min: min, Max: max, Currency: currency, Period: period}
+}
+
+// Annual returns the range per year, still in the posted currency.
+func (s Salary) Annual() (min, max float64) {
+	per := periodsPerYear[s.Period]
+	return s.Min * per, s.Max * per
+}
+
+// AnnualUSD converts the range to yearly USD with FXRates, or nils when the
+// currency has no rate.
+func (s Salary) AnnualUSD() (minPtr, maxPtr *int) {
+	rate, ok := FXRates[s.Currency]
+	lo, hi := s.Annual()
+	if !ok || hi <= 0 {
+		return nil, nil
+	}
+	min := int(math.Round(lo * rate))
+	max := int(math.Round(hi * rate))
+	return &min, &max
+}
+
+// SalaryToRangeUSD parses s and returns its annual USD range, or nils.
+func SalaryToRangeUSD(s string) (minPtr, maxPtr *int) {
+	sal, ok := ParseSalary(s)
+	if !ok {
+		return nil, nil
+	}
+	return sal.AnnualUSD()
+}
+
+// parseAmount reads one amountRe match, returning the number and its k/m
+// multiplier. Commas are thousands separators; a dot followed by exactly
+// three digits is one too ("90.000 €").
+func parseAmount(s string, m []int) (float64, float64, bool) {
+	num := s[m[2]:m[3]]
+	mult := 1.0
+	if m[4] >= 0 {
+		switch strings.TrimSpace(s[m[4]:m[5]]) {
+		case "k":
+			mult = 1000
+		case "m":
+			mult = 1000000
+		}
+	}
+	num = strings.ReplaceAll(num, ",", "")
+	if i := strings.LastIndex(num, "."); i >= 0 && len(num)-i-1 == 3 {
+		num = strings.ReplaceAll(num, ".", "")
+	}
+	v, err := strconv.ParseFloat(num, 64)
+	return v, mult, err == nil
+}
+
+func currencyOf(lower string) string {
+	if c := currencyCodeRe.FindString(lower); c != "" {
+		return strings.ToUpper(c)
+	}
+	for _, p := range currencyPrefixes {
+		if strings.Contains(lower, p.mark) {
+			return p.code
+		}
+	}
+	return ""
+}
+
+func periodOf(lower string) string {
+	period, at := "", len(lower)
+	for _, p := range periodWords {
+		for _, w := range p.words {
+			if i := strings.Index(lower, w); i >= 0 && i < at {
+				period, at = p.period, i
+			}
+		}
+	}
+	return period
+}
@@ -0,0 +1,65 @@
+package normalize
+
+import "testing"
+
+func TestParseSalary(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Salary
+	}{
+		{"$55/hr", Salary{55, 55, "USD", "hour"}},
+		{"$150,000 - $180,000 USD + equity", Salary{150000, 180000, "USD", "year"}},
+		{"$120K–$140K per year", Salary{120000, 140000, "USD", "year"}},
+		{"CAD 120K–140K", Salary{120000, 140000, "CAD", "year"}},
+		{"C$95,000 to C$110,000", Salary{95000, 110000, "CAD", "year"}},
+		{"€90,000", Salary{90000, 90000, "EUR", "year"}},
+		{"90.000 € per annum", Salary{90000, 90000, "EUR", "year"}},
+		{"£45 - £55 an hour", Salary{45, 55, "GBP", "hour"}},
+		{"$8,500/month", Salary{8500, 8500, "USD", "month"}},
+		{"$150k per year, 40 hours per week", Salary{150000, 150000, "USD", "year"}},
+		{"$60", Salary{60, 60, "USD", "hour"}},
+	}
+	for _, tt := range tests {
+		got, ok := ParseSalary(tt.in)
+		if !ok || got != tt.want {
+			t.Errorf("ParseSalary(%q) = %+v, %v; want %+v", tt.in, got, ok, tt.want)
+		}
+	}
+	for _, in := range []string{"", "Competitive", "DOE"} {
+		if got, ok := ParseSalary(in); ok {
+			t.Errorf("ParseSalary(%q) = %+v, want no salary", in, got)
+		}
+	}
+}
+
+func TestSalaryAnnualUSD(t *testing.T) {
+	tests := []struct {
+		in       string
+		min, max int
+	}{
+		{"$50/hr", 104000, 104000},
+		{"$8,000 - $10,000/month", 96000, 120000},
+		{"£100,000", 127000, 127000},
+	}
+	for _, tt := range tests {
+		min, max := SalaryToRangeUSD(tt.in)
+		if min == nil || max == nil || *min != tt.min || *max != tt.max {
+			t.Errorf("SalaryToRangeUSD(%q) = %v - %v, want %d - %d", tt.in, min, max, tt.min, tt.max)
+		}
+	}
+	if min, max := NewSalary(100, 200, "XYZ", "year").AnnualUSD(); min != nil || max != nil {
+		t.Errorf("unknown currency converted to %v - %v", min, max)
+	}
+}
+
+func TestParseFXRates(t *testing.T) {
+	rates, err := ParseFXRates("eur=1.1, GBP=1.3")
+	if err != nil || rates["EUR"] != 1.1 || rates["GBP"] != 1.3 {
+		t.Errorf("ParseFXRates = %v, %v", rates, err)
+	}
+	for _, bad := range []string{"EUR", "EUR=x", "EUR=-1"} {
+		if _, err := ParseFXRates(bad); err == nil {
+			t.Errorf("ParseFXRates(%q) accepted", bad)
+		}
+	}
+}
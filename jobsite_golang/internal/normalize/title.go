@@ -0,0 +1,64 @@
+package normalize
+
+import "regexp"
+
+// Seniorities lists every seniority ClassifyTitle returns, most junior first.
+var Seniorities = []string{"intern", "junior", "mid", "senior", "staff", "principal", "lead", "manager"}
+
+// RoleFamilies lists every role family ClassifyTitle returns.
+var RoleFamilies = []string{"sdet", "qa-automation", "manual-qa", "mobile-qa", "performance", "sre", "other"}
+
+// seniorityRules are tried in order, so "Senior QA Manager" is a manager and
+// "Staff SDET II" is staff. Titles matching none are mid.
+var seniorityRules = []struct {
+	level string
+	re    *regexp.Regexp
+}{
+	{"intern", regexp.MustCompile(`(?i)\b(?:intern|internship|co-?op|apprentice)\b`)},
+	{"manager", regexp.MustCompile(`(?i)\b(?:manager|director|head of|vp|vice president)\b`)},
+	{"principal", regexp.MustCompile(`(?i)\b(?:principal|distinguished|architect)\b`)},
+	{"staff", regexp.MustCompile(`(?i)\bstaff\b`)},
+	{"lead", regexp.MustCompile(`(?i)\b(?:lead|leader)\b`)},
+	{"senior", regexp.MustCompile(`(?i)\b(?:senior|sr|snr)\b|\b(?:iii|iv|v)\s*$|\b(?:iii|iv)\b|\b(?:level|l)\s*[3-5]\b`)},
+	{"junior", regexp.MustCompile(`(?i)\b(?:junior|jr|entry[- ]level|associate|graduate|new grad)\b|\bi\s*$|\b(?:level|l)\s*1\b`)},
+}
+
+var (
+	qaWordRe = regexp.MustCompile(`(?i)\b(?:qa|qe|quality|test|tests|tester|testing|sdet)\b|\bSET\b`)
+	// familyRules are tried in order; QA families need a QA word somewhere in
+	// the title except where the rule says otherwise.
+	familyRules = []struct {
+		family string
+		re     *regexp.Regexp
+		needQA bool
+	}{
+		{"performance", regexp.MustCompile(`(?i)\b(?:performance|perf|load|stress|scalability)\b`), true},
+		{"mobile-qa", regexp.MustCompile(`(?i)\b(?:mobile|ios|android|appium)\b`), true},
+		{"sdet", regexp.MustCompile(`(?i:\b(?:sdet|software (?:development |design )?engineer(?:ing)? in test|developer in test)\b)|\bSET\b`), false},
+		{"manual-qa", regexp.MustCompile(`(?i)\b(?:manual|tester|analyst|specialist|technician|uat)\b`), true},
+		{"qa-automation", regexp.MustCompile(`(?i)\b(?:automation|automated|qa|qe|quality|test|testing)\b`), true},
+		{"sre", regexp.MustCompile(`(?i)\b(?:sre|site reliability|reliability engineer|devops|platform engineer|infrastructure engineer)\b`), false},
+	}
+)
+
+// ClassifyTitle reads a job title's seniority (see Seniorities) and role
+// family (see RoleFamilies), e.g. "Sr. Software Engineer in Test" is
+// senior, sdet and "QA Analyst" is mid, manual-qa.
+func ClassifyTitle(title string) (seniority, family string) {
+	seniority = "mid"
+	for _, r := range seniorityRules {
+		if r.re.MatchString(title) {
+			seniority = r.level
+			break
+		}
+	}
+	family = "other"
+	qa := qaWordRe.MatchString(title)
+	for _, r := range familyRules {
+		if (qa || !r.needQA) && r.re.MatchString(title) {
+			family = r.family
+			break
+		}
+	}
+	return seniority, family
+}
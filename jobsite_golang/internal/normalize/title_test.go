@@ -0,0 +1,31 @@
+package normalize
+
+import "testing"
+
+func TestClassifyTitle(t *testing.T) {
+	tests := []struct {
+		title, seniority, family string
+	}{
+		{"Sr. Software Engineer in Test", "senior", "sdet"},
+		{"QA Analyst", "mid", "manual-qa"},
+		{"Senior QA Manager", "manager", "qa-automation"},
+		{"Staff SDET II", "staff", "sdet"},
+		{"SDET III", "senior", "sdet"},
+		{"Junior QA Tester", "junior", "manual-qa"},
+		{"QA Engineer I", "junior", "qa-automation"},
+		{"QA Automation Intern", "intern", "qa-automation"},
+		{"Principal Quality Engineer", "principal", "qa-automation"},
+		{"Lead Mobile QA Engineer (Appium)", "lead", "mobile-qa"},
+		{"Performance Test Engineer", "mid", "performance"},
+		{"Senior Site Reliability Engineer", "senior", "sre"},
+		{"Senior Software Engineer", "senior", "other"},
+		{"Android Developer", "mid", "other"},
+		{"Load Balancer Engineer", "mid", "other"},
+	}
+	for _, tt := range tests {
+		s, f := ClassifyTitle(tt.title)
+		if s != tt.seniority || f != tt.family {
+			t.Errorf("ClassifyTitle(%q) = %q, %q; want %q, %q", tt.title, s, f, tt.seniority, tt.family)
+		}
+	}
+}
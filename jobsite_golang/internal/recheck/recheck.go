@@ -0,0 +1,154 @@
+package recheck
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"jobsite/internal/extract"
+	"jobsite/internal/fetch"
+)
+
+// Result of re-fetching a posting. Err is set when the check itself failed
+// (network trouble, 5xx), in which case Closed is meaningless.
+type Result struct {
+	Closed bool
+	Reason string
+	Err    error
+}
+
+// closedMarkers are phrases ATS pages show in place of a removed posting,
+// keyed by a host fragment. The "" entry applies to every host. They are
+// matched against the visible text only: SPA script bundles carry strings
+// like "job not found" on every page.
+var closedMarkers = map[string][]string{
+	"": {
+		"no longer accepting applications",
+		"job is no longer available",
+		"position is no longer available",
+		"this position has been filled",
+		"posting has expired",
+		"job has expired",
+		"job posting is no longer",
+	},
+	"greenhouse.io": {
+		"the job you are looking for is no longer open",
+		"job not found",
+	},
+	"lever.co": {
+		"sorry, we couldn't find anything here",
+	},
+	"ashbyhq.com": {
+		"job not found",
+	},
+	"workable.com": {
+		"this job is no longer available",
+		"page not found",
+	},
+	"smartrecruiters.com": {
+		"sorry, this job has expired",
+		"this job is no longer available",
+	},
+	"myworkdayjobs.com": {
+		"the page you are looking for doesn't exist",
+	},
+}
+
+// closedData are markers in the data an ATS's single-page app is rendered
+// from, matched against the raw page, keyed like closedMarkers.
+var closedData = map[string][]string{
+	"ashbyhq.com": {`"posting":null`},
+}
+
+// Check re-fetches rawURL and reports whether the posting has been taken down.
+func Check(rawURL string) Result {
+	page, err := fetch.GetPage(rawURL)
+	if err != nil {
+		if fetch.IsGone(err) {
+			return Result{Closed: true, Reason: err.Error()}
+		}
+		return Result{Err: err}
+	}
+	closed, reason := Closed(rawURL, page)
+	if !closed {
+		closed, reason = Expired(extract.FromPage(rawURL, page.Body).ValidThrough, time.Now())
+	}
+	return Result{Closed: closed, Reason: reason}
+}
+
+// Expired reports whether a posting's schema.org validThrough date is
+// before now's date. Dates that don't parse never expire.
+func Expired(validThrough string, now time.Time) (bool, string) {
+	t, err := time.Parse("2006-01-02", validThrough)
+	if err != nil || !t.Before(now.UTC().Truncate(24*time.Hour)) {
+		return false, ""
+	}
+	return true, "validThrough " + validThrough
+}
+
+// Closed inspects an already-fetched page for signs the posting at rawURL was
+// removed: a redirect up to the company's board, or a "no longer available"
+// message for that ATS.
+func Closed(rawURL string, page *fetch.Page) (bool, string) {
+	if redirectedToBoard(rawURL, page.URL) {
+		return true, "redirected to " + page.URL
+	}
+	host := ""
+	if u, err := url.Parse(rawURL); err == nil {
+		host = u.Hostname()
+	}
+	if m := findMarker(host, closedMarkers, strings.ToLower(extract.Text(page.Body))); m != "" {
+		return true, "page says " + `"` + m + `"`
+	}
+	if m := findMarker(host, closedData, page.Body); m != "" {
+		return true, "page data has " + m
+	}
+	return false, ""
+}
+
+// findMarker returns the first of markers for host found in s, or "".
+func findMarker(host string, markers map[string][]string, s string) string {
+	for frag, list := range markers {
+		if frag != "" && !strings.HasSuffix(host, frag) {
+			continue
+		}
+		for _, m := range list {
+			if strings.Contains(s, m) {
+				return m
+			}
+		}
+	}
+	return ""
+}
+
+// redirectedToBoard reports whether final is a shallower page on the same
+// site than orig (e.g. /acme/jobs/123 → /acme) or an ATS error redirect.
+func redirectedToBoard(orig, final string) bool {
+	o, err := url.Parse(orig)
+	if err != nil {
+		return false
+	}
+	f, err := url.Parse(final)
+	if err != nil {
+		return false
+	}
+	if f.Query().Get("error") == "true" {
+		return true
+	}
+	op := strings.Trim(o.Path, "/")
+	fp := strings.Trim(f.Path, "/")
+	if op == fp || !strings.EqualFold(registrable(o.Hostname()), registrable(f.Hostname())) {
+		return false
+	}
+	return fp == "" || strings.HasPrefix(op, fp+"/")
+}
+
+// registrable approximates the site a host belongs to by its last two labels,
+// so job-boards.greenhouse.io and boards.greenhouse.io compare equal.
+func registrable(host string) string {
+	parts := strings.Split(host, ".")
+	if len(parts) <= 2 {
+		return host
+	}
+	return strings.Join(parts[len(parts)-2:], ".")
+}
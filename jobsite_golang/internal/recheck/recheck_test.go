@@ -0,0 +1,51 @@
+package recheck
+
+import (
+	"testing"
+	"time"
+
+	"jobsite/internal/fetch"
+)
+
+func TestExpired(t *testing.T) {
+	now := time.Date(2026, 10, 17, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		validThrough string
+		want         bool
+	}{
+		{"2026-10-16", true},
+		{"2026-10-17", false},
+		{"2026-11-01", false},
+		{"", false},
+		{"next month", false},
+	}
+	for _, tt := range tests {
+		if got, _ := Expired(tt.validThrough, now); got != tt.want {
+			t.Errorf("Expired(%q) = %v, want %v", tt.validThrough, got, tt.want)
+		}
+	}
+}
+
+func TestClosed(t *testing.T) {
+	tests := []struct {
+		url, final, body string
+		want             bool
+	}{
+		{"https://boards.greenhouse.io/acme/jobs/1", "https://boards.greenhouse.io/acme", "", true},
+		{"https://boards.greenhouse.io/acme/jobs/1", "https://boards.greenhouse.io/acme?error=true", "", true},
+		{"https://boards.greenhouse.io/acme/jobs/1", "https://job-boards.greenhouse.io/acme/jobs/1", "<h1>SDET</h1>", false},
+		{"https://jobs.lever.co/acme/1", "https://jobs.lever.co/acme/1", "Sorry, we couldn't find anything here", true},
+		{"https://jobs.lever.co/acme/1", "https://jobs.lever.co/acme/1", "Job not found", false},
+		{"https://acme.com/careers/1", "https://acme.com/careers/1", "This position has been filled.", true},
+		// Marker strings inside scripts are an SPA's i18n bundle, not the page.
+		{"https://boards.greenhouse.io/acme/jobs/1", "https://boards.greenhouse.io/acme/jobs/1",
+			`<h1>SDET</h1><script>var i18n = {"notFound": "Job not found", "missing": "Page not found"}</script>`, false},
+		{"https://jobs.ashbyhq.com/acme/1", "https://jobs.ashbyhq.com/acme/1", `<script>window.__appData = {"posting":null}</script>`, true},
+		{"https://jobs.ashbyhq.com/acme/1", "https://jobs.ashbyhq.com/acme/1", `<script>window.__appData = {"posting":{"title":"SDET"}}</script>`, false},
+	}
+	for _, tt := range tests {
+		if got, reason := Closed(tt.url, &fetch.Page{URL: tt.final, Body: tt.body}); got != tt.want {
+			t.Errorf("Closed(%q → %q) = %v (%s), want %v", tt.url, tt.final, got, reason, tt.want)
+		}
+	}
+}
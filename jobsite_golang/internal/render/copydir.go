@@ -40,7 +40,9 @@ func copyFile(src, dst string) error {
 	if err != nil {
 		return err
 	}
-	defer d.Close()
-	_, err = io.Copy(d, s)
-	return err
+	if _, err := io.Copy(d, s); err != nil {
+		d.Close()
+		return err
+	}
+	return d.Close()
 }
@@ -0,0 +1,179 @@
+package render
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"os"
+	"strings"
+	"time"
+
+	"jobsite/internal/model"
+)
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title     string     `xml:"title"`
+	ID        string     `xml:"id"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+	Links     []atomLink `xml:"link"`
+	Author    atomPerson `xml:"author"`
+	Summary   string     `xml:"summary"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	SelfLink    atomLink  `xml:"atom:link"`
+	LastBuild   string    `xml:"lastBuildDate"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Description string  `xml:"description"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+// jobGUID is a stable identifier derived from the job's canonical URL, so an
+// entry keeps its identity across daily rebuilds.
+func jobGUID(j model.Job) string {
+	sum := sha1.Sum([]byte(j.URL))
+	return "urn:sha1:" + hex.EncodeToString(sum[:])
+}
+
+func entryTitle(j model.Job) string {
+	if j.Company == "" {
+		return j.Title
+	}
+	return j.Title + " — " + j.Company
+}
+
+// entrySummary is the one-line description shared by every feed format.
+func entrySummary(j model.Job) string {
+	parts := []string{}
+	for _, p := range []string{j.Company, j.Location, j.SalaryRaw} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	if j.IsRemoteUS {
+		parts = append(parts, "Remote (US)")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// discovered returns the job's discovered date as a time, or now if unset.
+func discovered(j model.Job) time.Time {
+	if t, err := time.Parse("2006-01-02", j.DiscoveredDate); err == nil {
+		return t
+	}
+	return time.Now().UTC()
+}
+
+func writeAtom(path, siteTitle, baseURL string, jobs []model.Job) error {
+	base := strings.TrimRight(baseURL, "/")
+	f := atomFeed{
+		Title:   siteTitle,
+		ID:      base + "/",
+		Updated: time.Now().UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: base + "/atom.xml", Rel: "self", Type: "application/atom+xml"},
+			{Href: base + "/", Rel: "alternate", Type: "text/html"},
+		},
+	}
+	for _, j := range jobs {
+		ts := discovered(j).Format(time.RFC3339)
+		e := atomEntry{
+			Title:     entryTitle(j),
+			ID:        jobGUID(j),
+			Published: ts,
+			Updated:   ts,
+			Links:     []atomLink{{Href: j.URL, Rel: "alternate"}},
+			Author:    atomPerson{Name: j.Company},
+			Summary:   entrySummary(j),
+		}
+		// Atom requires an author on every entry when the feed has none.
+		if e.Author.Name == "" {
+			e.Author.Name = siteTitle
+		}
+		f.Entries = append(f.Entries, e)
+	}
+	return writeXML(path, f)
+}
+
+func writeRSS(path, siteTitle, baseURL string, jobs []model.Job) error {
+	base := strings.TrimRight(baseURL, "/")
+	f := rssFeed{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:       siteTitle,
+			Link:        base + "/",
+			Description: siteTitle,
+			SelfLink:    atomLink{Href: base + "/rss.xml", Rel: "self", Type: "application/rss+xml"},
+			LastBuild:   time.Now().UTC().Format(time.RFC1123Z),
+		},
+	}
+	for _, j := range jobs {
+		f.Channel.Items = append(f.Channel.Items, rssItem{
+			Title:       entryTitle(j),
+			Link:        j.URL,
+			GUID:        rssGUID{Value: jobGUID(j)},
+			PubDate:     discovered(j).Format(time.RFC1123Z),
+			Description: entrySummary(j),
+		})
+	}
+	return writeXML(path, f)
+}
+
+func writeXML(path string, v any) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.WriteString(xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(f)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return enc.Close()
+}
@@ -0,0 +1,103 @@
+package render
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"jobsite/internal/model"
+)
+
+var feedJobs = []model.Job{
+	{URL: "https://boards.greenhouse.io/acme/jobs/1", Title: "SDET", Company: "Acme", Location: "Remote - US", DiscoveredDate: "2026-10-16", IsRemoteUS: true},
+	{URL: "https://jobs.lever.co/anon/2", Title: "QA Engineer", DiscoveredDate: "2026-10-15"},
+}
+
+func readXML(t *testing.T, path string, v any) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := xml.Unmarshal(b, v); err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestWriteAtom(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "atom.xml")
+	if err := writeAtom(path, "QA Roles", "https://jobs.example.com/", feedJobs); err != nil {
+		t.Fatal(err)
+	}
+	var f atomFeed
+	readXML(t, path, &f)
+	if f.ID != "https://jobs.example.com/" || len(f.Links) == 0 || f.Links[0].Rel != "self" || f.Links[0].Href != "https://jobs.example.com/atom.xml" {
+		t.Errorf("feed id %q links %+v", f.ID, f.Links)
+	}
+	if _, err := time.Parse(time.RFC3339, f.Updated); err != nil {
+		t.Errorf("updated: %v", err)
+	}
+	if len(f.Entries) != 2 {
+		t.Fatalf("%d entries", len(f.Entries))
+	}
+	e := f.Entries[0]
+	if e.ID != jobGUID(feedJobs[0]) || e.Published != "2026-10-16T00:00:00Z" || e.Title != "SDET — Acme" || e.Author.Name != "Acme" {
+		t.Errorf("entry = %+v", e)
+	}
+	if e.Summary != "Acme · Remote - US · Remote (US)" {
+		t.Errorf("summary = %q", e.Summary)
+	}
+	if a := f.Entries[1].Author.Name; a != "QA Roles" {
+		t.Errorf("author without a company = %q, want the feed title", a)
+	}
+}
+
+func TestWriteRSS(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rss.xml")
+	if err := writeRSS(path, "QA Roles", "https://jobs.example.com", feedJobs); err != nil {
+		t.Fatal(err)
+	}
+	var f rssFeed
+	raw := readXML(t, path, &f)
+	// encoding/xml can't read the prefixed atom:link back, so check the text.
+	for _, want := range []string{
+		`<link>https://jobs.example.com/</link>`,
+		`<atom:link href="https://jobs.example.com/rss.xml" rel="self" type="application/rss+xml"></atom:link>`,
+	} {
+		if !strings.Contains(raw, want) {
+			t.Errorf("rss.xml lacks %s", want)
+		}
+	}
+	c := f.Channel
+	if f.Version != "2.0" || c.Title != "QA Roles" {
+		t.Errorf("channel = %+v", c)
+	}
+	if _, err := time.Parse(time.RFC1123Z, c.LastBuild); err != nil {
+		t.Errorf("lastBuildDate: %v", err)
+	}
+	if len(c.Items) != 2 {
+		t.Fatalf("%d items", len(c.Items))
+	}
+	it := c.Items[1]
+	if it.GUID.Value != jobGUID(feedJobs[1]) || it.GUID.IsPermaLink || it.PubDate != "Thu, 15 Oct 2026 00:00:00 +0000" || it.Title != "QA Engineer" {
+		t.Errorf("item = %+v", it)
+	}
+}
+
+func TestJobGUIDStable(t *testing.T) {
+	a := model.Job{URL: "https://boards.greenhouse.io/acme/jobs/1", Title: "SDET", Score: 10}
+	b := model.Job{URL: a.URL, Title: "Senior SDET", Company: "Acme", Score: 90}
+	if jobGUID(a) != jobGUID(b) {
+		t.Error("GUID changed with fields other than the URL")
+	}
+	if jobGUID(a) == jobGUID(model.Job{URL: "https://boards.greenhouse.io/acme/jobs/2"}) {
+		t.Error("different URLs share a GUID")
+	}
+	if g := jobGUID(a); len(g) != len("urn:sha1:")+40 {
+		t.Errorf("GUID = %q", g)
+	}
+}
@@ -0,0 +1,160 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"jobsite/internal/model"
+)
+
+// FeedDef is a named filter that gets its own JSON Feed at feeds/<Name>.json.
+// Empty criteria match everything; set criteria must all match.
+type FeedDef struct {
+	Name         string `json:"name"`
+	Title        string `json:"title"`
+	RemoteUS     bool   `json:"remote_us,omitempty"`
+	Location     string `json:"location,omitempty"` // case-insensitive substring of the location
+	MinSalaryUSD int    `json:"min_salary_usd,omitempty"`
+	Source       string `json:"source,omitempty"` // e.g. "Greenhouse"
+	MinScore     int    `json:"min_score,omitempty"`
+}
+
+var feedNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// DefaultFeedDefs is used when no feed definitions file is configured.
+func DefaultFeedDefs() []FeedDef {
+	return []FeedDef{
+		{Name: "remote-us", Title: "Remote (US)", RemoteUS: true},
+		{Name: "wichita", Title: "Wichita, KS", Location: "wichita"},
+		{Name: "salary-150k", Title: "$150k+", MinSalaryUSD: 150000},
+		{Name: "top-matches", Title: "Top matches", MinScore: 70},
+		{Name: "greenhouse", Title: "Greenhouse", Source: "Greenhouse"},
+		{Name: "lever", Title: "Lever", Source: "Lever"},
+		{Name: "ashby", Title: "Ashby", Source: "Ashby"},
+	}
+}
+
+// LoadFeedDefs reads a JSON array of feed definitions.
+func LoadFeedDefs(path string) ([]FeedDef, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var defs []FeedDef
+	if err := json.Unmarshal(b, &defs); err != nil {
+		return nil, err
+	}
+	for _, d := range defs {
+		if !feedNameRe.MatchString(d.Name) {
+			return nil, fmt.Errorf("feed name %q must be lowercase letters, digits, - or _", d.Name)
+		}
+	}
+	return defs, nil
+}
+
+// Match reports whether j belongs in the feed.
+func (d FeedDef) Match(j model.Job) bool {
+	if d.RemoteUS && !j.IsRemoteUS {
+		return false
+	}
+	if d.Location != "" && !strings.Contains(strings.ToLower(j.Location), strings.ToLower(d.Location)) {
+		return false
+	}
+	if d.MinSalaryUSD > 0 && (j.SalaryMaxUSD == nil || *j.SalaryMaxUSD < d.MinSalaryUSD) {
+		return false
+	}
+	if d.Source != "" && !strings.EqualFold(j.Source, d.Source) {
+		return false
+	}
+	if j.Score < d.MinScore {
+		return false
+	}
+	return true
+}
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	FeedURL     string         `json:"feed_url"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	Title         string           `json:"title"`
+	ContentText   string           `json:"content_text"`
+	Summary       string           `json:"summary,omitempty"`
+	DatePublished string           `json:"date_published"`
+	Authors       []jsonFeedAuthor `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+// writeJSONFeeds writes feed.json with every job plus feeds/<name>.json for
+// each definition.
+func writeJSONFeeds(dir, siteTitle, baseURL string, jobs []model.Job, defs []FeedDef) error {
+	if err := writeJSONFeed(filepath.Join(dir, "feed.json"), siteTitle, baseURL, "feed.json", jobs); err != nil {
+		return err
+	}
+	if len(defs) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "feeds"), 0o755); err != nil {
+		return err
+	}
+	for _, d := range defs {
+		var matched []model.Job
+		for _, j := range jobs {
+			if d.Match(j) {
+				matched = append(matched, j)
+			}
+		}
+		title := siteTitle + " — " + d.Title
+		rel := "feeds/" + d.Name + ".json"
+		if err := writeJSONFeed(filepath.Join(dir, rel), title, baseURL, rel, matched); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeJSONFeed(path, title, baseURL, rel string, jobs []model.Job) error {
+	base := strings.TrimRight(baseURL, "/")
+	f := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       title,
+		HomePageURL: base + "/",
+		FeedURL:     base + "/" + rel,
+		Items:       []jsonFeedItem{},
+	}
+	for _, j := range jobs {
+		it := jsonFeedItem{
+			ID:            jobGUID(j),
+			URL:           j.URL,
+			Title:         entryTitle(j),
+			ContentText:   entrySummary(j),
+			Summary:       j.Snippet,
+			DatePublished: discovered(j).Format(time.RFC3339),
+		}
+		if j.Company != "" {
+			it.Authors = []jsonFeedAuthor{{Name: j.Company}}
+		}
+		for _, t := range strings.Split(j.Tags, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				it.Tags = append(it.Tags, t)
+			}
+		}
+		f.Items = append(f.Items, it)
+	}
+	return writeJSON(path, f)
+}
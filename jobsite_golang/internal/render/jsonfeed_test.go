@@ -0,0 +1,121 @@
+package render
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"jobsite/internal/model"
+)
+
+func intPtr(n int) *int { return &n }
+
+func TestFeedDefMatch(t *testing.T) {
+	job := model.Job{Location: "Wichita, KS", IsRemoteUS: true, SalaryMaxUSD: intPtr(160000), Source: "Greenhouse", Score: 75}
+	tests := []struct {
+		name string
+		def  FeedDef
+		job  model.Job
+		want bool
+	}{
+		{"empty matches all", FeedDef{}, model.Job{}, true},
+		{"remote", FeedDef{RemoteUS: true}, job, true},
+		{"not remote", FeedDef{RemoteUS: true}, model.Job{}, false},
+		{"location any case", FeedDef{Location: "WICHITA"}, job, true},
+		{"location elsewhere", FeedDef{Location: "austin"}, job, false},
+		{"salary at floor", FeedDef{MinSalaryUSD: 160000}, job, true},
+		{"salary below", FeedDef{MinSalaryUSD: 170000}, job, false},
+		{"salary unknown", FeedDef{MinSalaryUSD: 1}, model.Job{}, false},
+		{"source any case", FeedDef{Source: "greenhouse"}, job, true},
+		{"other source", FeedDef{Source: "Lever"}, job, false},
+		{"score at floor", FeedDef{MinScore: 75}, job, true},
+		{"score below", FeedDef{MinScore: 76}, job, false},
+		{"all criteria", FeedDef{RemoteUS: true, Location: "wichita", MinSalaryUSD: 150000, Source: "Greenhouse", MinScore: 70}, job, true},
+		{"one criterion fails", FeedDef{RemoteUS: true, Location: "wichita", Source: "Ashby"}, job, false},
+	}
+	for _, tt := range tests {
+		if got := tt.def.Match(tt.job); got != tt.want {
+			t.Errorf("%s: Match = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLoadFeedDefs(t *testing.T) {
+	tests := []struct {
+		body    string
+		wantErr bool
+	}{
+		{`[{"name":"remote-us","title":"Remote","remote_us":true},{"name":"qa_150k","title":"$150k+","min_salary_usd":150000}]`, false},
+		{`[]`, false},
+		{`[{"name":"Remote","title":"x"}]`, true},
+		{`[{"name":"","title":"x"}]`, true},
+		{`[{"name":"-lead","title":"x"}]`, true},
+		{`[{"name":"../escape","title":"x"}]`, true},
+		{`[{"name":"a b","title":"x"}]`, true},
+		{`{"name":"remote-us"}`, true},
+	}
+	for _, tt := range tests {
+		if _, err := LoadFeedDefs(writeTemp(t, tt.body)); (err != nil) != tt.wantErr {
+			t.Errorf("LoadFeedDefs(%s) error = %v, want error %v", tt.body, err, tt.wantErr)
+		}
+	}
+	defs, err := LoadFeedDefs(writeTemp(t, `[{"name":"salary","title":"Paid","min_salary_usd":150000}]`))
+	if err != nil || len(defs) != 1 || defs[0].MinSalaryUSD != 150000 || defs[0].Title != "Paid" {
+		t.Errorf("LoadFeedDefs = %+v, %v", defs, err)
+	}
+	if _, err := LoadFeedDefs(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("missing file: no error")
+	}
+}
+
+func writeTemp(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "feeds.json")
+	writeFile(t, path, body)
+	return path
+}
+
+func TestWriteJSONFeeds(t *testing.T) {
+	dir := t.TempDir()
+	jobs := []model.Job{
+		{URL: "https://boards.greenhouse.io/acme/jobs/1", Title: "SDET", Company: "Acme", IsRemoteUS: true, Tags: "playwright, ci", DiscoveredDate: "2026-10-16"},
+		{URL: "https://jobs.lever.co/globex/2", Title: "QA Analyst", Location: "Wichita, KS", DiscoveredDate: "2026-10-15"},
+	}
+	defs := []FeedDef{{Name: "remote-us", Title: "Remote (US)", RemoteUS: true}, {Name: "none", Title: "Nothing", MinScore: 100}}
+	if err := writeJSONFeeds(dir, "QA Roles", "https://jobs.example.com/", jobs, defs); err != nil {
+		t.Fatal(err)
+	}
+	read := func(rel string) jsonFeed {
+		t.Helper()
+		b, err := os.ReadFile(filepath.Join(dir, rel))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var f jsonFeed
+		if err := json.Unmarshal(b, &f); err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	all := read("feed.json")
+	if all.FeedURL != "https://jobs.example.com/feed.json" || len(all.Items) != 2 {
+		t.Fatalf("feed.json = %+v", all)
+	}
+	it := all.Items[0]
+	if it.ID != jobGUID(jobs[0]) || it.DatePublished != "2026-10-16T00:00:00Z" || len(it.Authors) != 1 || len(it.Tags) != 2 || it.Tags[1] != "ci" {
+		t.Errorf("item = %+v", it)
+	}
+	if len(all.Items[1].Authors) != 0 {
+		t.Errorf("author without a company: %+v", all.Items[1].Authors)
+	}
+
+	remote := read("feeds/remote-us.json")
+	if remote.Title != "QA Roles — Remote (US)" || remote.FeedURL != "https://jobs.example.com/feeds/remote-us.json" || len(remote.Items) != 1 || remote.Items[0].URL != jobs[0].URL {
+		t.Errorf("remote-us feed = %+v", remote)
+	}
+	if none := read("feeds/none.json"); none.Items == nil || len(none.Items) != 0 {
+		t.Errorf("empty feed items = %#v, want []", none.Items)
+	}
+}
@@ -0,0 +1,113 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// snapshotPrefix names the hidden directories that outDir/latest points at.
+const snapshotPrefix = ".latest-"
+
+// frontendFiles are what the React build (Vite, outDir public/latest)
+// writes into latest. They are the only things carried from one snapshot to
+// the next; everything else comes from the day's directory, so files that
+// are no longer generated disappear.
+var frontendFiles = []string{"index.html", "assets"}
+
+// publishLatest builds a fresh snapshot directory from src plus the
+// frontend build in the current latest, then repoints the outDir/latest
+// symlink at it with a single rename, so the web server sees either the
+// previous site or the new one, never an empty or partial copy. On any
+// error the previous latest is left as it was.
+func publishLatest(outDir, src string) error {
+	snap, err := os.MkdirTemp(outDir, snapshotPrefix)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(snap, 0o755); err != nil {
+		os.RemoveAll(snap)
+		return err
+	}
+	latest := filepath.Join(outDir, "latest")
+	for _, name := range frontendFiles {
+		if err := copyPath(filepath.Join(latest, name), filepath.Join(snap, name)); err != nil {
+			os.RemoveAll(snap)
+			return fmt.Errorf("stage latest: %w", err)
+		}
+	}
+	if err := copyDir(src, snap); err != nil {
+		os.RemoveAll(snap)
+		return fmt.Errorf("stage latest: %w", err)
+	}
+	if err := swapSymlink(latest, filepath.Base(snap)); err != nil {
+		os.RemoveAll(snap)
+		return fmt.Errorf("publish latest: %w", err)
+	}
+	pruneSnapshots(outDir, filepath.Base(snap))
+	return nil
+}
+
+// copyPath copies the file or directory at src to dst. A missing src is
+// not an error: the frontend may not have been built yet.
+func copyPath(src, dst string) error {
+	fi, err := os.Stat(src)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		return copyDir(src, dst)
+	}
+	return copyFile(src, dst)
+}
+
+// swapSymlink atomically makes link a symlink to target.
+func swapSymlink(link, target string) error {
+	tmp := link + ".tmp"
+	os.Remove(tmp)
+	if err := os.Symlink(target, tmp); err != nil {
+		return err
+	}
+	// Older versions published latest as a plain directory, which rename
+	// can't replace. Move it aside first; this one-time switch is the only
+	// non-atomic step.
+	if fi, err := os.Lstat(link); err == nil && fi.IsDir() {
+		legacy := link + ".old"
+		os.RemoveAll(legacy)
+		if err := os.Rename(link, legacy); err != nil {
+			os.Remove(tmp)
+			return err
+		}
+		if err := os.Rename(tmp, link); err != nil {
+			os.Rename(legacy, link)
+			os.Remove(tmp)
+			return err
+		}
+		return os.RemoveAll(legacy)
+	}
+	if err := os.Rename(tmp, link); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// pruneSnapshots removes every snapshot directory except keep. Failures are
+// harmless leftovers and are ignored.
+func pruneSnapshots(outDir, keep string) {
+	entries, err := os.ReadDir(outDir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), snapshotPrefix) && e.Name() != keep {
+			os.RemoveAll(filepath.Join(outDir, e.Name()))
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPublishLatestDropsStaleFiles(t *testing.T) {
+	out := t.TempDir()
+	day1 := filepath.Join(out, "2026-10-16")
+	writeFile(t, filepath.Join(day1, "jobs.json"), "[1]")
+	writeFile(t, filepath.Join(day1, "feeds", "old-feed.json"), "{}")
+	if err := publishLatest(out, day1); err != nil {
+		t.Fatal(err)
+	}
+	// The frontend build lands in latest between runs.
+	latest := filepath.Join(out, "latest")
+	writeFile(t, filepath.Join(latest, "index.html"), "<div id=root>")
+	writeFile(t, filepath.Join(latest, "assets", "app.js"), "app")
+
+	day2 := filepath.Join(out, "2026-10-17")
+	writeFile(t, filepath.Join(day2, "jobs.json"), "[2]")
+	if err := publishLatest(out, day2); err != nil {
+		t.Fatal(err)
+	}
+
+	if b, err := os.ReadFile(filepath.Join(latest, "jobs.json")); err != nil || string(b) != "[2]" {
+		t.Errorf("jobs.json = %q, %v", b, err)
+	}
+	for _, name := range []string{"index.html", "assets/app.js"} {
+		if _, err := os.Stat(filepath.Join(latest, name)); err != nil {
+			t.Errorf("frontend file %s not carried over: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(latest, "feeds", "old-feed.json")); !os.IsNotExist(err) {
+		t.Errorf("stale feeds/old-feed.json still published (err %v)", err)
+	}
+	snaps, _ := filepath.Glob(filepath.Join(out, snapshotPrefix+"*"))
+	if len(snaps) != 1 {
+		t.Errorf("%d snapshots left, want 1", len(snaps))
+	}
+}
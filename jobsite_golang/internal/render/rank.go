@@ -0,0 +1,23 @@
+package render
+
+import (
+	"sort"
+
+	"jobsite/internal/model"
+)
+
+// Rank orders jobs for publishing and drops those scored below minScore.
+// sortBy "score" puts the best matches first, keeping the incoming order
+// (newest first) among equal scores; anything else keeps that order.
+func Rank(jobs []model.Job, sortBy string, minScore int) []model.Job {
+	out := make([]model.Job, 0, len(jobs))
+	for _, j := range jobs {
+		if j.Score >= minScore {
+			out = append(out, j)
+		}
+	}
+	if sortBy == "score" {
+		sort.SliceStable(out, func(i, k int) bool { return out[i].Score > out[k].Score })
+	}
+	return out
+}
@@ -6,6 +6,7 @@ import (
 	"html/template"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"jobsite/internal/model"
@@ -18,10 +19,13 @@ type DailyPageData struct {
 	Jobs      []model.Job
 }
 
-func WriteDaily(outDir string, siteTitle, baseURL string, jobs []model.Job) (string, error) {
+func WriteDaily(outDir string, siteTitle, baseURL string, jobs []model.Job, feeds []FeedDef) (string, error) {
 	day := time.Now().Format("2006-01-02")
 	dayDir := filepath.Join(outDir, day)
-	_ = os.MkdirAll(dayDir, 0o755)
+	if err := os.MkdirAll(dayDir, 0o755); err != nil {
+		return "", err
+	}
+	jobs = withSnippets(jobs)
 	// HTML generation removed - React frontend handles UI
 	// if err := writeHTML(filepath.Join(dayDir, "index.html"), "templates/daily.html.tmpl", DailyPageData{
 	// 	SiteTitle: siteTitle, Day: day, BaseURL: baseURL, Jobs: jobs,
@@ -34,12 +38,48 @@ func WriteDaily(outDir string, siteTitle, baseURL string, jobs []model.Job) (str
 	if err := writeJSON(filepath.Join(dayDir, "jobs.json"), jobs); err != nil {
 		return "", err
 	}
-	latest := filepath.Join(outDir, "latest")
-	_ = os.RemoveAll(latest)
-	_ = copyDir(dayDir, latest)
+	// Feeds live at the site root (public/latest), so their self links do too.
+	if err := writeAtom(filepath.Join(dayDir, "atom.xml"), siteTitle, baseURL, jobs); err != nil {
+		return "", err
+	}
+	if err := writeRSS(filepath.Join(dayDir, "rss.xml"), siteTitle, baseURL, jobs); err != nil {
+		return "", err
+	}
+	if err := writeJSONFeeds(dayDir, siteTitle, baseURL, jobs, feeds); err != nil {
+		return "", err
+	}
+	if err := publishLatest(outDir, dayDir); err != nil {
+		return "", err
+	}
 	return dayDir, nil
 }
 
+// snippetLen caps description_snippet in jobs.json, in runes.
+const snippetLen = 280
+
+// withSnippets returns a copy of jobs with Snippet cut from each description.
+func withSnippets(jobs []model.Job) []model.Job {
+	out := make([]model.Job, len(jobs))
+	for i, j := range jobs {
+		j.Snippet = snippet(j.Description, snippetLen)
+		out[i] = j
+	}
+	return out
+}
+
+// snippet shortens text to at most n runes, breaking at a word.
+func snippet(text string, n int) string {
+	r := []rune(text)
+	if len(r) <= n {
+		return text
+	}
+	cut := string(r[:n])
+	if i := strings.LastIndex(cut, " "); i > n/2 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:") + "…"
+}
+
 func writeHTML(path, tplPath string, data any) error {
 	t, err := template.ParseFiles(tplPath)
 	if err != nil {
@@ -61,14 +101,14 @@ func writeCSV(path string, jobs []model.Job) error {
 	defer f.Close()
 	w := csv.NewWriter(f)
 	defer w.Flush()
-	w.Write([]string{"title", "company", "location", "salary_range", "url", "source", "discovered_date"})
+	w.Write([]string{"title", "company", "location", "salary_range", "url", "source", "discovered_date", "seniority", "role_family"})
 	for _, j := range jobs {
-		_ = w.Write([]string{j.Title, j.Company, j.Location, j.SalaryRaw, j.URL, j.Source, j.DiscoveredDate})
+		_ = w.Write([]string{j.Title, j.Company, j.Location, j.SalaryRaw, j.URL, j.Source, j.DiscoveredDate, j.Seniority, j.RoleFamily})
 	}
 	return nil
 }
 
-func writeJSON(path string, jobs []model.Job) error {
+func writeJSON(path string, v any) error {
 	f, err := os.Create(path)
 	if err != nil {
 		return err
@@ -76,5 +116,5 @@ func writeJSON(path string, jobs []model.Job) error {
 	defer f.Close()
 	enc := json.NewEncoder(f)
 	enc.SetIndent("", "  ")
-	return enc.Encode(jobs)
+	return enc.Encode(v)
 }
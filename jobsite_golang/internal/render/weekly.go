@@ -0,0 +1,175 @@
+package render
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"jobsite/internal/model"
+)
+
+// WeeklySummary is the aggregate written to summary.json and the weekly page.
+type WeeklySummary struct {
+	Week        string         `json:"week"`
+	From        string         `json:"from"`
+	To          string         `json:"to"`
+	NewCount    int            `json:"new_count"`
+	ClosedCount int            `json:"closed_count"`
+	ByCompany   []WeeklyGroup  `json:"by_company"`
+	BySource    []WeeklyGroup  `json:"by_source"`
+	Salary      []SalaryBucket `json:"salary_distribution"`
+}
+
+// WeeklyGroup counts the week's new and closed postings for one company or source.
+type WeeklyGroup struct {
+	Name   string      `json:"name"`
+	New    int         `json:"new"`
+	Closed int         `json:"closed"`
+	Jobs   []model.Job `json:"-"`
+}
+
+// SalaryBucket counts new postings whose top of range falls in [Min, Max).
+type SalaryBucket struct {
+	Label string `json:"label"`
+	Min   int    `json:"min"`
+	Max   int    `json:"max,omitempty"`
+	Count int    `json:"count"`
+}
+
+type WeeklyPageData struct {
+	SiteTitle string
+	BaseURL   string
+	Summary   WeeklySummary
+	Jobs      []model.Job
+}
+
+// ISOWeek returns the "2006-W01" label and the Monday..Sunday dates of the
+// ISO week containing t.
+func ISOWeek(t time.Time) (label, from, to string) {
+	year, week := t.ISOWeek()
+	offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+	monday := t.AddDate(0, 0, -offset)
+	return fmt.Sprintf("%d-W%02d", year, week), monday.Format("2006-01-02"), monday.AddDate(0, 0, 6).Format("2006-01-02")
+}
+
+// ParseISOWeek returns the Monday of an ISO week written as "2026-W42". Week
+// 53 is accepted only in years that have one.
+func ParseISOWeek(s string) (time.Time, error) {
+	var year, week int
+	if _, err := fmt.Sscanf(s, "%d-W%d", &year, &week); err == nil && week >= 1 && week <= 53 {
+		// January 4th is always in week 1.
+		jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+		week1 := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+		monday := week1.AddDate(0, 0, (week-1)*7)
+		if y, w := monday.ISOWeek(); y == year && w == week {
+			return monday, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid ISO week %q (want e.g. 2026-W42)", s)
+}
+
+// WriteWeekly writes the bundle for the ISO week containing day to
+// outDir/YYYY-Www: jobs discovered that week as JSON and CSV, summary.json and
+// index.html. closed holds postings closed during the week.
+func WriteWeekly(outDir, siteTitle, baseURL string, day time.Time, jobs, closed []model.Job) (string, error) {
+	label, from, to := ISOWeek(day)
+	weekDir := filepath.Join(outDir, label)
+	if err := os.MkdirAll(weekDir, 0o755); err != nil {
+		return "", err
+	}
+	jobs = withSnippets(jobs)
+	summary := summarizeWeek(label, from, to, jobs, closed)
+
+	if err := writeCSV(filepath.Join(weekDir, "jobs.csv"), jobs); err != nil {
+		return "", err
+	}
+	if err := writeJSON(filepath.Join(weekDir, "jobs.json"), jobs); err != nil {
+		return "", err
+	}
+	if err := writeJSON(filepath.Join(weekDir, "summary.json"), summary); err != nil {
+		return "", err
+	}
+	if err := writeHTML(filepath.Join(weekDir, "index.html"), "templates/weekly.html.tmpl", WeeklyPageData{
+		SiteTitle: siteTitle, BaseURL: baseURL, Summary: summary, Jobs: jobs,
+	}); err != nil {
+		return "", err
+	}
+	return weekDir, nil
+}
+
+func summarizeWeek(label, from, to string, jobs, closed []model.Job) WeeklySummary {
+	s := WeeklySummary{Week: label, From: from, To: to, NewCount: len(jobs), ClosedCount: len(closed)}
+
+	companies := map[string]*WeeklyGroup{}
+	sources := map[string]*WeeklyGroup{}
+	group := func(m map[string]*WeeklyGroup, name string) *WeeklyGroup {
+		if name == "" {
+			name = "Unknown"
+		}
+		g, ok := m[name]
+		if !ok {
+			g = &WeeklyGroup{Name: name}
+			m[name] = g
+		}
+		return g
+	}
+	for _, j := range jobs {
+		c := group(companies, j.Company)
+		c.New++
+		c.Jobs = append(c.Jobs, j)
+		src := group(sources, j.Source)
+		src.New++
+		src.Jobs = append(src.Jobs, j)
+	}
+	for _, j := range closed {
+		group(companies, j.Company).Closed++
+		group(sources, j.Source).Closed++
+	}
+	s.ByCompany = sortedGroups(companies)
+	s.BySource = sortedGroups(sources)
+
+	s.Salary = []SalaryBucket{
+		{Label: "Under $100k", Min: 0, Max: 100000},
+		{Label: "$100k–125k", Min: 100000, Max: 125000},
+		{Label: "$125k–150k", Min: 125000, Max: 150000},
+		{Label: "$150k–175k", Min: 150000, Max: 175000},
+		{Label: "$175k–200k", Min: 175000, Max: 200000},
+		{Label: "$200k+", Min: 200000},
+		{Label: "Not listed"},
+	}
+	unlisted := len(s.Salary) - 1
+	for _, j := range jobs {
+		if j.SalaryMaxUSD == nil {
+			s.Salary[unlisted].Count++
+			continue
+		}
+		for i := 0; i < unlisted; i++ {
+			b := &s.Salary[i]
+			if *j.SalaryMaxUSD >= b.Min && (b.Max == 0 || *j.SalaryMaxUSD < b.Max) {
+				b.Count++
+				break
+			}
+		}
+	}
+	return s
+}
+
+// sortedGroups orders groups by new postings, then closed, then name.
+func sortedGroups(m map[string]*WeeklyGroup) []WeeklyGroup {
+	out := make([]WeeklyGroup, 0, len(m))
+	for _, g := range m {
+		out = append(out, *g)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].New != out[j].New {
+			return out[i].New > out[j].New
+		}
+		if out[i].Closed != out[j].Closed {
+			return out[i].Closed > out[j].Closed
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
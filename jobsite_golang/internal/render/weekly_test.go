@@ -0,0 +1,93 @@
+package render
+
+import (
+	"testing"
+	"time"
+
+	"jobsite/internal/model"
+)
+
+func TestISOWeek(t *testing.T) {
+	tests := []struct{ day, label, from, to string }{
+		{"2026-10-17", "2026-W42", "2026-10-12", "2026-10-18"},
+		{"2026-10-12", "2026-W42", "2026-10-12", "2026-10-18"}, // Monday
+		{"2026-10-18", "2026-W42", "2026-10-12", "2026-10-18"}, // Sunday
+		{"2027-01-01", "2026-W53", "2026-12-28", "2027-01-03"}, // W53 runs into the new year
+		{"2024-12-30", "2025-W01", "2024-12-30", "2025-01-05"}, // week 1 starts in the old year
+		{"2021-01-03", "2020-W53", "2020-12-28", "2021-01-03"},
+	}
+	for _, tt := range tests {
+		day, _ := time.Parse("2006-01-02", tt.day)
+		label, from, to := ISOWeek(day)
+		if label != tt.label || from != tt.from || to != tt.to {
+			t.Errorf("ISOWeek(%s) = %s %s..%s, want %s %s..%s", tt.day, label, from, to, tt.label, tt.from, tt.to)
+		}
+	}
+}
+
+func TestParseISOWeek(t *testing.T) {
+	tests := []struct{ in, monday string }{
+		{"2026-W42", "2026-10-12"},
+		{"2026-W01", "2025-12-29"},
+		{"2026-W53", "2026-12-28"},
+		{"2025-W01", "2024-12-30"},
+		{"2020-W53", "2020-12-28"},
+	}
+	for _, tt := range tests {
+		got, err := ParseISOWeek(tt.in)
+		if err != nil || got.Format("2006-01-02") != tt.monday {
+			t.Errorf("ParseISOWeek(%s) = %s, %v; want %s", tt.in, got.Format("2006-01-02"), err, tt.monday)
+			continue
+		}
+		if label, _, _ := ISOWeek(got); label != tt.in {
+			t.Errorf("ISOWeek(ParseISOWeek(%s)) = %s", tt.in, label)
+		}
+	}
+	for _, in := range []string{"2025-W53", "2026-W00", "2026-W54", "2026-42", "W42", ""} {
+		if got, err := ParseISOWeek(in); err == nil {
+			t.Errorf("ParseISOWeek(%q) = %s, want an error", in, got)
+		}
+	}
+}
+
+func TestSummarizeWeek(t *testing.T) {
+	jobs := []model.Job{
+		{Company: "Acme", Source: "Greenhouse", SalaryMaxUSD: intPtr(99999)},
+		{Company: "Acme", Source: "Lever", SalaryMaxUSD: intPtr(100000)},
+		{Company: "Globex", Source: "Greenhouse", SalaryMaxUSD: intPtr(175000)},
+		{Company: "", Source: "Greenhouse", SalaryMaxUSD: intPtr(250000)},
+		{Company: "Initech", Source: "Ashby"},
+	}
+	closed := []model.Job{
+		{Company: "Globex", Source: "Lever"},
+		{Company: "Umbrella", Source: "Lever"},
+	}
+	s := summarizeWeek("2026-W42", "2026-10-12", "2026-10-18", jobs, closed)
+	if s.NewCount != 5 || s.ClosedCount != 2 {
+		t.Errorf("counts = %d new, %d closed", s.NewCount, s.ClosedCount)
+	}
+
+	want := []WeeklyGroup{{Name: "Acme", New: 2}, {Name: "Globex", New: 1, Closed: 1}, {Name: "Initech", New: 1}, {Name: "Unknown", New: 1}, {Name: "Umbrella", Closed: 1}}
+	if len(s.ByCompany) != len(want) {
+		t.Fatalf("by company = %+v", s.ByCompany)
+	}
+	for i, g := range s.ByCompany {
+		if g.Name != want[i].Name || g.New != want[i].New || g.Closed != want[i].Closed || len(g.Jobs) != g.New {
+			t.Errorf("by company %d = %s %d/%d, want %s %d/%d", i, g.Name, g.New, g.Closed, want[i].Name, want[i].New, want[i].Closed)
+		}
+	}
+	if src := s.BySource; len(src) != 3 || src[0].Name != "Greenhouse" || src[0].New != 3 || src[1].Name != "Lever" || src[1].Closed != 2 {
+		t.Errorf("by source = %+v", src)
+	}
+
+	counts := map[string]int{}
+	for _, b := range s.Salary {
+		counts[b.Label] = b.Count
+	}
+	wantCounts := map[string]int{"Under $100k": 1, "$100k–125k": 1, "$125k–150k": 0, "$150k–175k": 0, "$175k–200k": 1, "$200k+": 1, "Not listed": 1}
+	for label, n := range wantCounts {
+		if counts[label] != n {
+			t.Errorf("bucket %s = %d, want %d", label, counts[label], n)
+		}
+	}
+}
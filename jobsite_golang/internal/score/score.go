@@ -0,0 +1,249 @@
+package score
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"jobsite/internal/model"
+	"jobsite/internal/normalize"
+)
+
+// Profile describes the job being looked for. Skills are matched against a
+// job's tags and, as whole words, its title and description; seniorities
+// and role families use normalize.Seniorities and normalize.RoleFamilies.
+// An empty preference is always met.
+type Profile struct {
+	MustHave       map[string]float64 `json:"must_have"`    // skill → weight
+	NiceToHave     map[string]float64 `json:"nice_to_have"` // skill → weight
+	Seniority      []string           `json:"seniority"`
+	RoleFamilies   []string           `json:"role_families"`
+	SalaryFloorUSD int                `json:"salary_floor_usd"`
+	RemoteUS       bool               `json:"remote_us"` // remote-from-the-US jobs meet the location preference
+	States         []string           `json:"states"`    // US state codes where on-site or hybrid work is fine
+	Cities         []string           `json:"cities"`
+	Penalties      map[string]float64 `json:"penalty_keywords"` // keyword → points off
+
+	once  sync.Once
+	words map[string]*regexp.Regexp // skill or keyword → whole-word pattern
+}
+
+// Points available per part of the score; they add up to 100 before
+// penalties.
+const (
+	mustHavePoints   = 25
+	niceToHavePoints = 10
+	seniorityPoints  = 15
+	familyPoints     = 10
+	locationPoints   = 20
+	salaryPoints     = 20
+)
+
+// DefaultProfile is used when no profile file is configured: a senior
+// automation-focused QA/SDET role, remote in the US or in Wichita, KS.
+func DefaultProfile() *Profile {
+	return &Profile{
+		MustHave:       map[string]float64{"playwright": 2, "selenium": 1, "cypress": 1, "appium": 1},
+		NiceToHave:     map[string]float64{"github-actions": 1, "typescript": 1, "python": 1, "api-testing": 1},
+		Seniority:      []string{"senior", "staff", "principal", "lead"},
+		RoleFamilies:   []string{"sdet", "qa-automation", "mobile-qa"},
+		SalaryFloorUSD: 120000,
+		RemoteUS:       true,
+		States:         []string{"KS"},
+		Cities:         []string{"Wichita"},
+		Penalties:      map[string]float64{"clearance": 30, "manual testing": 10, "contract": 10},
+	}
+}
+
+// Load reads a Profile from a JSON file.
+func Load(path string) (*Profile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p Profile
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, err
+	}
+	for _, s := range p.Seniority {
+		if !contains(normalize.Seniorities, s) {
+			return nil, fmt.Errorf("unknown seniority %q (want one of %s)", s, strings.Join(normalize.Seniorities, ", "))
+		}
+	}
+	for _, f := range p.RoleFamilies {
+		if !contains(normalize.RoleFamilies, f) {
+			return nil, fmt.Errorf("unknown role family %q (want one of %s)", f, strings.Join(normalize.RoleFamilies, ", "))
+		}
+	}
+	return &p, nil
+}
+
+// Score rates j against p from 0 to 100 and explains each part, e.g.
+// "must-have 25/25 (playwright, appium); seniority 15/15 (senior); ...".
+func (p *Profile) Score(j model.Job) (int, string) {
+	p.once.Do(p.compile)
+	text := strings.ToLower(j.Title + " " + j.Description)
+	tags := map[string]bool{}
+	for _, t := range strings.Split(j.Tags, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tags[t] = true
+		}
+	}
+
+	var total float64
+	var why []string
+	add := func(name string, got, max float64, detail string) {
+		total += got
+		part := fmt.Sprintf("%s %g/%g", name, math.Round(got), max)
+		if detail != "" {
+			part += " (" + detail + ")"
+		}
+		why = append(why, part)
+	}
+
+	got, detail := p.skillShare(p.MustHave, tags, text)
+	add("must-have", got*mustHavePoints, mustHavePoints, detail)
+	got, detail = p.skillShare(p.NiceToHave, tags, text)
+	add("nice-to-have", got*niceToHavePoints, niceToHavePoints, detail)
+	add("seniority", seniorityShare(p.Seniority, j.Seniority)*seniorityPoints, seniorityPoints, j.Seniority)
+	familyShare := 0.0
+	if len(p.RoleFamilies) == 0 || contains(p.RoleFamilies, j.RoleFamily) {
+		familyShare = 1
+	}
+	add("role", familyShare*familyPoints, familyPoints, j.RoleFamily)
+	got, detail = p.locationShare(j)
+	add("location", got*locationPoints, locationPoints, detail)
+	got, detail = p.salaryShare(j)
+	add("salary", got*salaryPoints, salaryPoints, detail)
+
+	for _, kw := range sortedKeys(p.Penalties) {
+		if p.words[kw].MatchString(text) {
+			total -= p.Penalties[kw]
+			why = append(why, fmt.Sprintf("penalty -%g (%s)", p.Penalties[kw], kw))
+		}
+	}
+	return int(math.Round(math.Max(0, math.Min(100, total)))), strings.Join(why, "; ")
+}
+
+// skillShare is the weighted share of skills the job mentions, and which.
+func (p *Profile) skillShare(skills map[string]float64, tags map[string]bool, text string) (float64, string) {
+	if len(skills) == 0 {
+		return 1, ""
+	}
+	var have, all float64
+	var found []string
+	for _, s := range sortedKeys(skills) {
+		all += skills[s]
+		if tags[strings.ToLower(s)] || p.words[s].MatchString(text) {
+			have += skills[s]
+			found = append(found, s)
+		}
+	}
+	if all <= 0 {
+		return 1, ""
+	}
+	if len(found) == 0 {
+		return 0, "none"
+	}
+	return have / all, strings.Join(found, ", ")
+}
+
+// seniorityShare is full marks for a wanted level and half for one a step
+// away from any wanted level.
+func seniorityShare(wanted []string, level string) float64 {
+	if len(wanted) == 0 || contains(wanted, level) {
+		return 1
+	}
+	at := index(normalize.Seniorities, level)
+	for _, w := range wanted {
+		if d := index(normalize.Seniorities, w) - at; at >= 0 && (d == 1 || d == -1) {
+			return 0.5
+		}
+	}
+	return 0
+}
+
+// locationShare is full marks for a remote-eligible job (when wanted) or one
+// in a wanted state or city, and half for remote work of unknown reach.
+func (p *Profile) locationShare(j model.Job) (float64, string) {
+	if !p.RemoteUS && len(p.States) == 0 && len(p.Cities) == 0 {
+		return 1, ""
+	}
+	if p.RemoteUS && j.RemoteEligibility == normalize.RemoteYes {
+		return 1, "remote US"
+	}
+	for _, pl := range j.Places {
+		if pl.State != "" && containsFold(p.States, pl.State) {
+			return 1, pl.State
+		}
+		if pl.City != "" && containsFold(p.Cities, pl.City) {
+			return 1, pl.City
+		}
+	}
+	if p.RemoteUS && j.RemoteEligibility == normalize.RemoteUnknown && j.WorkplaceType == normalize.WorkplaceRemote {
+		return 0.5, "remote, region unknown"
+	}
+	return 0, "no match"
+}
+
+// salaryShare is full marks at or above the floor, proportional below it,
+// and half when no salary is posted.
+func (p *Profile) salaryShare(j model.Job) (float64, string) {
+	if p.SalaryFloorUSD <= 0 {
+		return 1, ""
+	}
+	if j.SalaryMaxUSD == nil || *j.SalaryMaxUSD <= 0 {
+		return 0.5, "not posted"
+	}
+	top := *j.SalaryMaxUSD
+	detail := fmt.Sprintf("$%dk", top/1000)
+	if top >= p.SalaryFloorUSD {
+		return 1, detail
+	}
+	return float64(top) / float64(p.SalaryFloorUSD), detail + " below floor"
+}
+
+// compile builds the whole-word pattern for every skill and penalty
+// keyword once, on first use; rescoring the whole database reuses them.
+func (p *Profile) compile() {
+	p.words = map[string]*regexp.Regexp{}
+	for _, m := range []map[string]float64{p.MustHave, p.NiceToHave, p.Penalties} {
+		for phrase := range m {
+			p.words[phrase] = regexp.MustCompile(`(?i)(?:^|[^a-z0-9])` + regexp.QuoteMeta(strings.ToLower(phrase)) + `(?:$|[^a-z0-9])`)
+		}
+	}
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(list []string, s string) bool { return index(list, s) >= 0 }
+
+func index(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
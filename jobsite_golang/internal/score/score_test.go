@@ -0,0 +1,62 @@
+package score
+
+import (
+	"strings"
+	"testing"
+
+	"jobsite/internal/model"
+	"jobsite/internal/normalize"
+)
+
+func salary(v int) *int { return &v }
+
+func TestScore(t *testing.T) {
+	p := DefaultProfile()
+	best := model.Job{
+		Title: "Senior SDET", Description: "Playwright, Selenium, Cypress and Appium; Python and TypeScript.",
+		Tags: "github-actions,api-testing", Seniority: "senior", RoleFamily: "sdet",
+		RemoteEligibility: normalize.RemoteYes, SalaryMaxUSD: salary(150000),
+	}
+	if got, why := p.Score(best); got != 100 {
+		t.Errorf("best job scored %d: %s", got, why)
+	}
+
+	penalized := best
+	penalized.Description += " Active secret clearance required."
+	if got, why := p.Score(penalized); got != 70 || !strings.Contains(why, "penalty -30 (clearance)") {
+		t.Errorf("clearance job scored %d: %s", got, why)
+	}
+
+	// Whole words only: "contractor" is not "contract", "playwrights" not "playwright".
+	partial := best
+	partial.Description = "We work with contractors and playwrights."
+	partial.Tags = ""
+	got, why := p.Score(partial)
+	if strings.Contains(why, "penalty") || !strings.Contains(why, "must-have 0/25 (none)") {
+		t.Errorf("partial words scored %d: %s", got, why)
+	}
+
+	weak := model.Job{Title: "QA Analyst", Seniority: "mid", RoleFamily: "manual-qa", RemoteEligibility: normalize.RemoteNo,
+		Places: []model.Place{{City: "Austin", State: "TX", Country: "US"}}, SalaryMaxUSD: salary(60000)}
+	if got, why := p.Score(weak); got >= 20 {
+		t.Errorf("weak job scored %d: %s", got, why)
+	}
+}
+
+func TestScoreLocation(t *testing.T) {
+	p := &Profile{RemoteUS: true, States: []string{"KS"}, Cities: []string{"Wichita"}}
+	tests := []struct {
+		job  model.Job
+		want int
+	}{
+		{model.Job{RemoteEligibility: normalize.RemoteYes}, 100},
+		{model.Job{Places: []model.Place{{City: "Wichita", State: "KS", Country: "US"}}}, 100},
+		{model.Job{RemoteEligibility: normalize.RemoteUnknown, WorkplaceType: normalize.WorkplaceRemote}, 90},
+		{model.Job{Places: []model.Place{{City: "Austin", State: "TX", Country: "US"}}}, 80},
+	}
+	for _, tt := range tests {
+		if got, why := p.Score(tt.job); got != tt.want {
+			t.Errorf("Score(%+v) = %d (%s), want %d", tt.job, got, why, tt.want)
+		}
+	}
+}
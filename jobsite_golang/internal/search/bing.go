@@ -0,0 +1,53 @@
+package search
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const bingURL = "https://api.bing.microsoft.com/v7.0/search"
+
+// Bing queries the Bing Web Search API.
+type Bing struct {
+	APIKey string
+	// BaseURL overrides the endpoint, e.g. to point at a local fake server.
+	BaseURL string
+}
+
+type bingResponse struct {
+	WebPages struct {
+		Value []struct {
+			URL string `json:"url"`
+		} `json:"value"`
+	} `json:"webPages"`
+}
+
+func (b Bing) Name() string { return "bing" }
+
+func (b Bing) Search(q string, max, start int) ([]string, error) {
+	endpoint := b.BaseURL
+	if endpoint == "" {
+		endpoint = bingURL
+	}
+	params := url.Values{
+		"q":      {q},
+		"count":  {"20"},
+		"offset": {strconv.Itoa(start)},
+	}
+	req, err := http.NewRequest("GET", endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Ocp-Apim-Subscription-Key", b.APIKey)
+
+	var br bingResponse
+	if err := doJSON(b.Name(), req, &br); err != nil {
+		return nil, err
+	}
+	links := make([]string, 0, len(br.WebPages.Value))
+	for _, r := range br.WebPages.Value {
+		links = append(links, r.URL)
+	}
+	return filterLinks(links, max), nil
+}
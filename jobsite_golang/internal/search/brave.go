@@ -0,0 +1,56 @@
+package search
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const braveURL = "https://api.search.brave.com/res/v1/web/search"
+
+// Brave queries the Brave Search web API.
+type Brave struct {
+	APIKey string
+	// BaseURL overrides the endpoint, e.g. to point at a local fake server.
+	BaseURL string
+}
+
+type braveResponse struct {
+	Web struct {
+		Results []struct {
+			URL string `json:"url"`
+		} `json:"results"`
+	} `json:"web"`
+}
+
+func (b Brave) Name() string { return "brave" }
+
+func (b Brave) Search(q string, max, start int) ([]string, error) {
+	endpoint := b.BaseURL
+	if endpoint == "" {
+		endpoint = braveURL
+	}
+	// Brave pages in units of count rather than by result offset.
+	const count = 20
+	params := url.Values{
+		"q":      {q},
+		"count":  {strconv.Itoa(count)},
+		"offset": {strconv.Itoa(start / count)},
+	}
+	req, err := http.NewRequest("GET", endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Subscription-Token", b.APIKey)
+
+	var br braveResponse
+	if err := doJSON(b.Name(), req, &br); err != nil {
+		return nil, err
+	}
+	links := make([]string, 0, len(br.Web.Results))
+	for _, r := range br.Web.Results {
+		links = append(links, r.URL)
+	}
+	return filterLinks(links, max), nil
+}
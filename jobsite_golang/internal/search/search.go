@@ -1,27 +1,34 @@
 package search
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 )
 
 var allowedHosts = map[string]bool{
-	"boards.greenhouse.io":     true,
-	"jobs.ashbyhq.com":         true,
-	"jobs.lever.co":            true,
-	"myworkdayjobs.com":        true,
-	"jobs.smartrecruiters.com": true,
-	"apply.workable.com":       true,
-	"recruiting.adp.com":       true,
-	"recruiting2.ultipro.com":  true,
-	"jobs.jobvite.com":         true,
+	"boards.greenhouse.io":        true,
+	"job-boards.greenhouse.io":    true,
+	"job-boards.eu.greenhouse.io": true,
+	"jobs.ashbyhq.com":            true,
+	"jobs.lever.co":               true,
+	"jobs.eu.lever.co":            true,
+	"jobs.smartrecruiters.com":    true,
+	"apply.workable.com":          true,
+	"recruiting.adp.com":          true,
+	"recruiting2.ultipro.com":     true,
+	"jobs.jobvite.com":            true,
 }
-var allowedSuffixes = []string{".icims.com", ".bamboohr.com", ".recruitee.com", ".breezy.hr"}
+
+// allowedSuffixes are ATSs that give each company its own subdomain, such as
+// acme.wd5.myworkdayjobs.com.
+var allowedSuffixes = []string{".myworkdayjobs.com", ".icims.com", ".bamboohr.com", ".recruitee.com", ".breezy.hr"}
 
 func hostAllowed(u *url.URL) bool {
 	if allowedHosts[u.Hostname()] {
@@ -36,78 +43,145 @@ func hostAllowed(u *url.URL) bool {
 	return false
 }
 
-type serperRequest struct {
-	Query string `json:"q"`
-	Num   int    `json:"num"`
-	Start int    `json:"start"`
-}
-
-type serperResponse struct {
-	Organic []struct {
-		Link string `json:"link"`
-	} `json:"organic"`
+// Provider is a web search backend that returns result links for a query.
+type Provider interface {
+	Name() string
+	// Search returns up to max ATS links for q, starting at result offset start.
+	Search(q string, max, start int) ([]string, error)
 }
 
-func SerpAPISearch(apiKey, q string, max int, start int) ([]string, error) {
+// New returns the named provider ("serper", "serpapi", "brave" or "bing")
+// authenticated with apiKey.
+func New(name, apiKey string) (Provider, error) {
 	if apiKey == "" {
-		return nil, errors.New("SERPER_API missing")
+		return nil, fmt.Errorf("%s: API key missing", name)
 	}
-	
-	// Use Serper API instead of SerpAPI
-	client := &http.Client{Timeout: 20 * time.Second}
-	
-	// Create JSON payload for Serper API
-	payload := serperRequest{
-		Query: q,
-		Num:   20,
-		Start: start,
-	}
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		return nil, err
+	switch strings.ToLower(name) {
+	case "serper":
+		return Serper{APIKey: apiKey}, nil
+	case "serpapi":
+		return SerpAPI{APIKey: apiKey}, nil
+	case "brave":
+		return Brave{APIKey: apiKey}, nil
+	case "bing":
+		return Bing{APIKey: apiKey}, nil
+	default:
+		return nil, fmt.Errorf("unknown search provider %q", name)
 	}
-	
-	req, err := http.NewRequest("POST", "https://google.serper.dev/search", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, err
+}
+
+// HTTPError is a non-2xx response from a provider.
+type HTTPError struct {
+	Provider   string
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%s: HTTP %d: %s", e.Provider, e.StatusCode, e.Body)
+}
+
+// Quota reports whether the provider refused the request for auth, billing or
+// rate-limit reasons, i.e. retrying it later in the same run is pointless.
+func (e *HTTPError) Quota() bool {
+	switch e.StatusCode {
+	case http.StatusUnauthorized, http.StatusPaymentRequired, http.StatusForbidden, http.StatusTooManyRequests:
+		return true
 	}
-	
-	req.Header.Add("X-API-KEY", apiKey)
-	req.Header.Add("Content-Type", "application/json")
-	
+	return false
+}
+
+var client = &http.Client{Timeout: 20 * time.Second}
+
+func doJSON(provider string, req *http.Request, v any) error {
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
-	
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	
-	var sr serperResponse
-	if err := json.Unmarshal(body, &sr); err != nil {
-		return nil, err
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg := strings.TrimSpace(string(body))
+		if len(msg) > 200 {
+			msg = msg[:200]
+		}
+		return &HTTPError{Provider: provider, StatusCode: resp.StatusCode, Body: msg}
 	}
-	
+	return json.Unmarshal(body, v)
+}
+
+// filterLinks keeps the first max unique links on allowed ATS hosts.
+func filterLinks(links []string, max int) []string {
 	out := make([]string, 0, max)
 	seen := map[string]bool{}
-	for _, r := range sr.Organic {
-		if r.Link == "" || seen[r.Link] {
+	for _, l := range links {
+		if l == "" || seen[l] {
 			continue
 		}
-		u, err := url.Parse(r.Link)
+		u, err := url.Parse(l)
 		if err != nil {
 			continue
 		}
 		if hostAllowed(u) {
-			seen[r.Link] = true
-			out = append(out, r.Link)
+			seen[l] = true
+			out = append(out, l)
 			if len(out) >= max {
 				break
 			}
 		}
 	}
-	return out, nil
+	return out
+}
+
+// Fallback tries each provider in order until one succeeds. A provider that
+// reports a quota error is skipped for the rest of the run.
+type Fallback struct {
+	providers []Provider
+	mu        sync.Mutex
+	exhausted map[string]bool
+}
+
+func NewFallback(providers ...Provider) *Fallback {
+	return &Fallback{providers: providers, exhausted: map[string]bool{}}
+}
+
+func (f *Fallback) Name() string {
+	if len(f.providers) == 0 {
+		return "none"
+	}
+	names := make([]string, len(f.providers))
+	for i, p := range f.providers {
+		names[i] = p.Name()
+	}
+	return strings.Join(names, ",")
+}
+
+func (f *Fallback) Search(q string, max, start int) ([]string, error) {
+	var errs []error
+	for _, p := range f.providers {
+		f.mu.Lock()
+		skip := f.exhausted[p.Name()]
+		f.mu.Unlock()
+		if skip {
+			continue
+		}
+		links, err := p.Search(q, max, start)
+		if err == nil {
+			return links, nil
+		}
+		var he *HTTPError
+		if errors.As(err, &he) && he.Quota() {
+			f.mu.Lock()
+			f.exhausted[p.Name()] = true
+			f.mu.Unlock()
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return nil, errors.New("no search provider available")
+	}
+	return nil, errors.Join(errs...)
 }
@@ -0,0 +1,186 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// results are what every fake provider returns: two ATS links, a duplicate
+// and a link on a host we don't scrape.
+var results = []string{
+	"https://boards.greenhouse.io/acme/jobs/1",
+	"https://www.linkedin.com/jobs/view/2",
+	"https://jobs.lever.co/acme/aaaa-1111",
+	"https://boards.greenhouse.io/acme/jobs/1",
+}
+
+var wantLinks = []string{"https://boards.greenhouse.io/acme/jobs/1", "https://jobs.lever.co/acme/aaaa-1111"}
+
+func TestProviders(t *testing.T) {
+	tests := []struct {
+		name string
+		new  func(baseURL string) Provider
+		// check validates the request and returns the response body.
+		check func(t *testing.T, r *http.Request) any
+	}{
+		{"serper", func(u string) Provider { return Serper{APIKey: "k", BaseURL: u} }, func(t *testing.T, r *http.Request) any {
+			var req serperRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if r.Method != "POST" || r.Header.Get("X-API-KEY") != "k" || req.Query != "sdet" || req.Start != 20 {
+				t.Errorf("serper request: %s %+v key %q", r.Method, req, r.Header.Get("X-API-KEY"))
+			}
+			var out struct {
+				Organic []map[string]string `json:"organic"`
+			}
+			for _, l := range results {
+				out.Organic = append(out.Organic, map[string]string{"link": l})
+			}
+			return out
+		}},
+		{"serpapi", func(u string) Provider { return SerpAPI{APIKey: "k", BaseURL: u} }, func(t *testing.T, r *http.Request) any {
+			q := r.URL.Query()
+			if q.Get("api_key") != "k" || q.Get("q") != "sdet" || q.Get("start") != "20" || q.Get("engine") != "google" {
+				t.Errorf("serpapi query: %v", q)
+			}
+			var out struct {
+				OrganicResults []map[string]string `json:"organic_results"`
+			}
+			for _, l := range results {
+				out.OrganicResults = append(out.OrganicResults, map[string]string{"link": l})
+			}
+			return out
+		}},
+		{"brave", func(u string) Provider { return Brave{APIKey: "k", BaseURL: u} }, func(t *testing.T, r *http.Request) any {
+			q := r.URL.Query()
+			if r.Header.Get("X-Subscription-Token") != "k" || q.Get("q") != "sdet" || q.Get("offset") != "1" {
+				t.Errorf("brave query: %v token %q", q, r.Header.Get("X-Subscription-Token"))
+			}
+			var out struct {
+				Web struct {
+					Results []map[string]string `json:"results"`
+				} `json:"web"`
+			}
+			for _, l := range results {
+				out.Web.Results = append(out.Web.Results, map[string]string{"url": l})
+			}
+			return out
+		}},
+		{"bing", func(u string) Provider { return Bing{APIKey: "k", BaseURL: u} }, func(t *testing.T, r *http.Request) any {
+			q := r.URL.Query()
+			if r.Header.Get("Ocp-Apim-Subscription-Key") != "k" || q.Get("q") != "sdet" || q.Get("offset") != "20" {
+				t.Errorf("bing query: %v key %q", q, r.Header.Get("Ocp-Apim-Subscription-Key"))
+			}
+			var out struct {
+				WebPages struct {
+					Value []map[string]string `json:"value"`
+				} `json:"webPages"`
+			}
+			for _, l := range results {
+				out.WebPages.Value = append(out.WebPages.Value, map[string]string{"url": l})
+			}
+			return out
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(tt.check(t, r))
+			}))
+			defer srv.Close()
+
+			p := tt.new(srv.URL)
+			links, err := p.Search("sdet", 10, 20)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(links, wantLinks) {
+				t.Errorf("links = %q, want %q", links, wantLinks)
+			}
+			if links, _ := p.Search("sdet", 1, 20); len(links) != 1 {
+				t.Errorf("max 1 returned %d links", len(links))
+			}
+		})
+	}
+}
+
+func TestSerpAPIErrorField(t *testing.T) {
+	body := `{"error": "Invalid API key."}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(body)) }))
+	defer srv.Close()
+
+	if _, err := (SerpAPI{APIKey: "k", BaseURL: srv.URL}).Search("sdet", 10, 0); err == nil {
+		t.Error("error field not reported")
+	}
+	body = `{"error": "Google hasn't returned any results for this query."}`
+	if links, err := (SerpAPI{APIKey: "k", BaseURL: srv.URL}).Search("sdet", 10, 0); err != nil || len(links) != 0 {
+		t.Errorf("no results = %q, %v; want none and no error", links, err)
+	}
+}
+
+func TestFallbackSkipsExhaustedProvider(t *testing.T) {
+	calls := map[string]int{}
+	quota := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls["serper"]++
+		http.Error(w, "out of credits", http.StatusTooManyRequests)
+	}))
+	defer quota.Close()
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls["bing"]++
+		w.Write([]byte(`{"webPages": {"value": [{"url": "https://boards.greenhouse.io/acme/jobs/1"}]}}`))
+	}))
+	defer ok.Close()
+
+	f := NewFallback(Serper{APIKey: "k", BaseURL: quota.URL}, Bing{APIKey: "k", BaseURL: ok.URL})
+	for i := 0; i < 3; i++ {
+		links, err := f.Search("sdet", 10, 0)
+		if err != nil || len(links) != 1 {
+			t.Fatalf("search %d = %q, %v", i, links, err)
+		}
+	}
+	if calls["serper"] != 1 || calls["bing"] != 3 {
+		t.Errorf("calls = %v, want serper once then skipped", calls)
+	}
+}
+
+func TestFallbackReportsErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	_, err := NewFallback(Brave{APIKey: "k", BaseURL: srv.URL}).Search("sdet", 10, 0)
+	var he *HTTPError
+	if !errors.As(err, &he) || he.StatusCode != http.StatusInternalServerError || he.Quota() {
+		t.Errorf("err = %v, want a non-quota HTTPError", err)
+	}
+}
+
+func TestHostAllowed(t *testing.T) {
+	tests := []struct {
+		link string
+		want bool
+	}{
+		{"https://boards.greenhouse.io/acme/jobs/1", true},
+		{"https://job-boards.greenhouse.io/acme/jobs/1", true},
+		{"https://job-boards.eu.greenhouse.io/acme/jobs/1", true},
+		{"https://jobs.eu.lever.co/acme/aaaa-1111", true},
+		{"https://acme.wd5.myworkdayjobs.com/en-US/careers/job/QA_R1", true},
+		{"https://acme.icims.com/jobs/1/job", true},
+		{"https://myworkdayjobs.com.evil.example/job", false},
+		{"https://www.linkedin.com/jobs/view/2", false},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.link)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hostAllowed(u); got != tt.want {
+			t.Errorf("hostAllowed(%q) = %v, want %v", tt.link, got, tt.want)
+		}
+	}
+}
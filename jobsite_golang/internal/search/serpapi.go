@@ -0,0 +1,59 @@
+package search
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const serpAPIURL = "https://serpapi.com/search.json"
+
+// SerpAPI queries Google through serpapi.com.
+type SerpAPI struct {
+	APIKey string
+	// BaseURL overrides the endpoint, e.g. to point at a local fake server.
+	BaseURL string
+}
+
+type serpAPIResponse struct {
+	Error          string `json:"error"`
+	OrganicResults []struct {
+		Link string `json:"link"`
+	} `json:"organic_results"`
+}
+
+func (s SerpAPI) Name() string { return "serpapi" }
+
+func (s SerpAPI) Search(q string, max, start int) ([]string, error) {
+	endpoint := s.BaseURL
+	if endpoint == "" {
+		endpoint = serpAPIURL
+	}
+	params := url.Values{
+		"engine":  {"google"},
+		"q":       {q},
+		"num":     {"20"},
+		"start":   {strconv.Itoa(start)},
+		"api_key": {s.APIKey},
+	}
+	req, err := http.NewRequest("GET", endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var sr serpAPIResponse
+	if err := doJSON(s.Name(), req, &sr); err != nil {
+		return nil, err
+	}
+	// SerpAPI reports some failures as a 200 with an error field, including
+	// the harmless "no results" case.
+	if sr.Error != "" && len(sr.OrganicResults) == 0 && sr.Error != "Google hasn't returned any results for this query." {
+		return nil, errors.New("serpapi: " + sr.Error)
+	}
+	links := make([]string, 0, len(sr.OrganicResults))
+	for _, r := range sr.OrganicResults {
+		links = append(links, r.Link)
+	}
+	return filterLinks(links, max), nil
+}
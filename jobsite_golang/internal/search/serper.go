@@ -0,0 +1,57 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+)
+
+const serperURL = "https://google.serper.dev/search"
+
+// Serper queries Google through serper.dev.
+type Serper struct {
+	APIKey string
+	// BaseURL overrides the endpoint, e.g. to point at a local fake server.
+	BaseURL string
+}
+
+type serperRequest struct {
+	Query string `json:"q"`
+	Num   int    `json:"num"`
+	Start int    `json:"start"`
+}
+
+type serperResponse struct {
+	Organic []struct {
+		Link string `json:"link"`
+	} `json:"organic"`
+}
+
+func (s Serper) Name() string { return "serper" }
+
+func (s Serper) Search(q string, max, start int) ([]string, error) {
+	endpoint := s.BaseURL
+	if endpoint == "" {
+		endpoint = serperURL
+	}
+	jsonData, err := json.Marshal(serperRequest{Query: q, Num: 20, Start: start})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("X-API-KEY", s.APIKey)
+	req.Header.Add("Content-Type", "application/json")
+
+	var sr serperResponse
+	if err := doJSON(s.Name(), req, &sr); err != nil {
+		return nil, err
+	}
+	links := make([]string, 0, len(sr.Organic))
+	for _, r := range sr.Organic {
+		links = append(links, r.Link)
+	}
+	return filterLinks(links, max), nil
+}
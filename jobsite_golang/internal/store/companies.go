@@ -0,0 +1,189 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"strings"
+
+	"jobsite/internal/company"
+)
+
+// Company is a canonical employer and the names it has been posted under.
+type Company struct {
+	ID      int64
+	Name    string
+	Aliases []string
+	Jobs    int // open jobs
+}
+
+// queryRower is what resolveCompany needs from a *DB or an *sql.Tx.
+type queryRower interface {
+	execQuerier
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// resolveCompany returns the company posted as name, creating it on first
+// sight and recording each new spelling as an alias. A company first seen
+// only as a board slug takes the first properly spelled name that comes
+// along, unless its name was configured. An empty name resolves to 0.
+func resolveCompany(q queryRower, name string) (int64, string, error) {
+	name = strings.TrimSpace(name)
+	key := company.Key(name)
+	if key == "" {
+		return 0, name, nil
+	}
+	var id int64
+	var current string
+	var fromSlug bool
+	err := q.QueryRow(`SELECT c.id, c.name, c.named_from_slug AND NOT c.manual FROM company_aliases a JOIN companies c ON c.id=a.company_id
+WHERE a.alias_key=? ORDER BY c.id LIMIT 1`, key).Scan(&id, &current, &fromSlug)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		current = company.DisplayName(name)
+		res, err := q.Exec(`INSERT INTO companies (name, named_from_slug) VALUES (?,?)`, current, company.IsSlug(name))
+		if err != nil {
+			return 0, "", err
+		}
+		if id, err = res.LastInsertId(); err != nil {
+			return 0, "", err
+		}
+	case err != nil:
+		return 0, "", err
+	case fromSlug && !company.IsSlug(name):
+		current = company.DisplayName(name)
+		if _, err := q.Exec(`UPDATE companies SET name=?, named_from_slug=0 WHERE id=?`, current, id); err != nil {
+			return 0, "", err
+		}
+		if _, err := q.Exec(`UPDATE jobs SET company=? WHERE company_id=?`, current, id); err != nil {
+			return 0, "", err
+		}
+	}
+	if _, err := q.Exec(`INSERT OR IGNORE INTO company_aliases (alias, alias_key, company_id) VALUES (?,?,?)`, name, key, id); err != nil {
+		return 0, "", err
+	}
+	return id, current, nil
+}
+
+// LookupCompany returns the id and canonical name of the company posted as
+// name, or 0 when no company goes by that spelling. Unlike saving a job it
+// never creates a company.
+func LookupCompany(db *DB, name string) (int64, string, error) {
+	key := company.Key(name)
+	if key == "" {
+		return 0, "", nil
+	}
+	var id int64
+	var canonical string
+	err := db.QueryRow(`SELECT c.id, c.name FROM company_aliases a JOIN companies c ON c.id=a.company_id
+WHERE a.alias_key=? ORDER BY c.id LIMIT 1`, key).Scan(&id, &canonical)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, "", nil
+	}
+	return id, canonical, err
+}
+
+// SetCompanyAliases applies configured aliases: each canonical name gets its
+// own company (named exactly as given), and every alias is pointed at it,
+// moving jobs over from any company the alias used to resolve to.
+func SetCompanyAliases(db *DB, aliases company.Aliases) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for name, others := range aliases {
+		id, _, err := resolveCompany(tx, name)
+		if err != nil {
+			return err
+		}
+		if id == 0 {
+			continue
+		}
+		if _, err := tx.Exec(`UPDATE companies SET name=?, manual=1 WHERE id=?`, strings.TrimSpace(name), id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`UPDATE jobs SET company=? WHERE company_id=?`, strings.TrimSpace(name), id); err != nil {
+			return err
+		}
+		for _, alias := range others {
+			alias = strings.TrimSpace(alias)
+			other, _, err := resolveCompany(tx, alias)
+			if err != nil {
+				return err
+			}
+			if other != 0 && other != id {
+				if err := mergeCompany(tx, other, id); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// mergeCompany moves every alias and job of company from onto company to
+// and deletes from.
+func mergeCompany(tx *sql.Tx, from, to int64) error {
+	var name string
+	if err := tx.QueryRow(`SELECT name FROM companies WHERE id=?`, to).Scan(&name); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE company_aliases SET company_id=? WHERE company_id=?`, to, from); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE jobs SET company_id=?, company=? WHERE company_id=?`, to, name, from); err != nil {
+		return err
+	}
+	_, err := tx.Exec(`DELETE FROM companies WHERE id=?`, from)
+	return err
+}
+
+// Companies lists every company with its aliases and open job count, most
+// jobs first.
+func Companies(db *DB) ([]Company, error) {
+	rows, err := db.Query(`SELECT c.id, c.name,
+  (SELECT count(*) FROM jobs j WHERE j.company_id=c.id AND j.closed_at IS NULL)
+FROM companies c`)
+	if err != nil {
+		return nil, err
+	}
+	var out []Company
+	byID := map[int64]int{}
+	for rows.Next() {
+		var c Company
+		if err := rows.Scan(&c.ID, &c.Name, &c.Jobs); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		byID[c.ID] = len(out)
+		out = append(out, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.Query(`SELECT company_id, alias FROM company_aliases ORDER BY alias`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var alias string
+		if err := rows.Scan(&id, &alias); err != nil {
+			return nil, err
+		}
+		if i, ok := byID[id]; ok {
+			out[i].Aliases = append(out[i].Aliases, alias)
+		}
+	}
+	sort.SliceStable(out, func(i, k int) bool {
+		if out[i].Jobs != out[k].Jobs {
+			return out[i].Jobs > out[k].Jobs
+		}
+		return out[i].Name < out[k].Name
+	})
+	return out, rows.Err()
+}
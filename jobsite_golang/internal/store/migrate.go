@@ -0,0 +1,207 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// migration is one schema change. Versions are applied in ascending order,
+// each in its own transaction together with its schema_migrations row.
+type migration struct {
+	version int
+	name    string
+	up      func(tx *sql.Tx) error
+}
+
+// migrations is append-only: never edit or renumber an entry that has shipped.
+var migrations = []migration{
+	{1, "initial schema", execSQL(`
+CREATE TABLE IF NOT EXISTS jobs (
+  id INTEGER PRIMARY KEY,
+  url TEXT UNIQUE,
+  title TEXT, company TEXT, location TEXT,
+  salary_raw TEXT, salary_min_usd INTEGER, salary_max_usd INTEGER,
+  source TEXT, posted_date TEXT,
+  discovered_date TEXT NOT NULL,
+  is_remote_us INTEGER NOT NULL,
+  tags TEXT
+);
+CREATE TABLE IF NOT EXISTS runs (
+  run_id TEXT PRIMARY KEY,
+  started_at_utc TEXT, finished_at_utc TEXT,
+  query_count INTEGER, new_links INTEGER, pages_parsed INTEGER
+);`)},
+	// 2 and 3 were briefly added ad hoc on open, so they tolerate existing columns.
+	{2, "jobs.employment_type", addColumn("jobs", "employment_type", "TEXT NOT NULL DEFAULT ''")},
+	{3, "jobs.closed_at", addColumn("jobs", "closed_at", "TEXT")},
+	{4, "run statistics", execSQL(`
+ALTER TABLE runs ADD COLUMN mode TEXT NOT NULL DEFAULT '';
+ALTER TABLE runs ADD COLUMN fetch_failures INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE runs ADD COLUMN extract_failures INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE runs ADD COLUMN inserted INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE runs ADD COLUMN updated INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE runs ADD COLUMN status TEXT NOT NULL DEFAULT '';
+ALTER TABLE runs ADD COLUMN error TEXT NOT NULL DEFAULT '';
+CREATE TABLE run_queries (
+  run_id TEXT NOT NULL REFERENCES runs(run_id) ON DELETE CASCADE,
+  position INTEGER NOT NULL,
+  query TEXT NOT NULL,
+  links_found INTEGER NOT NULL,
+  search_errors INTEGER NOT NULL,
+  PRIMARY KEY (run_id, position)
+);`)},
+	{5, "jobs.description", execSQL(`
+ALTER TABLE jobs ADD COLUMN description TEXT NOT NULL DEFAULT '';
+ALTER TABLE jobs ADD COLUMN description_html TEXT NOT NULL DEFAULT '';`)},
+	{6, "jobs salary currency and period", execSQL(`
+ALTER TABLE jobs ADD COLUMN salary_currency TEXT NOT NULL DEFAULT '';
+ALTER TABLE jobs ADD COLUMN salary_period TEXT NOT NULL DEFAULT '';`)},
+	// 7 to 11 only change the schema: filling the new columns in for stored
+	// jobs runs today's parsers, so that is "jobsite renormalize", not a
+	// migration whose result would change with the code.
+	{7, "jobs places and workplace type", execSQL(`
+ALTER TABLE jobs ADD COLUMN places TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE jobs ADD COLUMN workplace_type TEXT NOT NULL DEFAULT '';`)},
+	{8, "jobs remote eligibility", execSQL(`
+ALTER TABLE jobs ADD COLUMN remote_eligibility TEXT NOT NULL DEFAULT 'unknown';
+ALTER TABLE jobs ADD COLUMN remote_evidence TEXT NOT NULL DEFAULT '';`)},
+	{9, "jobs duplicate links", execSQL(`
+ALTER TABLE jobs ADD COLUMN dedupe_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE jobs ADD COLUMN duplicate_of TEXT;
+CREATE INDEX jobs_dedupe_key ON jobs(dedupe_key);`)},
+	{10, "companies", execSQL(`
+CREATE TABLE companies (
+  id INTEGER PRIMARY KEY,
+  name TEXT NOT NULL,
+  named_from_slug INTEGER NOT NULL DEFAULT 0,
+  manual INTEGER NOT NULL DEFAULT 0
+);
+CREATE TABLE company_aliases (
+  alias TEXT PRIMARY KEY,
+  alias_key TEXT NOT NULL,
+  company_id INTEGER NOT NULL REFERENCES companies(id) ON DELETE CASCADE
+);
+CREATE INDEX company_aliases_key ON company_aliases(alias_key);
+ALTER TABLE jobs ADD COLUMN company_id INTEGER REFERENCES companies(id);`)},
+	{11, "jobs seniority and role family", execSQL(`
+ALTER TABLE jobs ADD COLUMN seniority TEXT NOT NULL DEFAULT '';
+ALTER TABLE jobs ADD COLUMN role_family TEXT NOT NULL DEFAULT '';`)},
+	// Scores depend on the configured profile; "jobsite rescore" fills them in.
+	{12, "jobs score", execSQL(`
+ALTER TABLE jobs ADD COLUMN score INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE jobs ADD COLUMN score_explanation TEXT NOT NULL DEFAULT '';`)},
+	{13, "rejections", execSQL(`
+CREATE TABLE rejections (
+  url TEXT PRIMARY KEY,
+  title TEXT NOT NULL, company TEXT NOT NULL, location TEXT NOT NULL,
+  reason TEXT NOT NULL,
+  run_id TEXT NOT NULL,
+  rejected_at_utc TEXT NOT NULL
+);
+CREATE INDEX rejections_rejected_at ON rejections(rejected_at_utc);
+ALTER TABLE runs ADD COLUMN rejected INTEGER NOT NULL DEFAULT 0;`)},
+}
+
+func execSQL(stmts string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		_, err := tx.Exec(stmts)
+		return err
+	}
+}
+
+// addColumn adds a column unless the table already has it.
+func addColumn(table, column, decl string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		var n int
+		if err := tx.QueryRow(`SELECT count(*) FROM pragma_table_info(?) WHERE name=?`, table, column).Scan(&n); err != nil {
+			return err
+		}
+		if n > 0 {
+			return nil
+		}
+		_, err := tx.Exec(`ALTER TABLE ` + table + ` ADD COLUMN ` + column + ` ` + decl)
+		return err
+	}
+}
+
+// MigrationInfo describes one known migration; AppliedAt is empty while pending.
+type MigrationInfo struct {
+	Version   int
+	Name      string
+	AppliedAt string
+}
+
+// MigrationStatus lists every known migration and when it was applied.
+func MigrationStatus(db *DB) ([]MigrationInfo, error) {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]MigrationInfo, 0, len(migrations))
+	for _, m := range migrations {
+		out = append(out, MigrationInfo{Version: m.version, Name: m.name, AppliedAt: applied[m.version]})
+	}
+	return out, nil
+}
+
+// Migrate applies all pending migrations in order and returns the ones it ran.
+// It stops at the first failure, leaving that migration unapplied.
+func Migrate(db *DB) ([]MigrationInfo, error) {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	var ran []MigrationInfo
+	for _, m := range migrations {
+		if applied[m.version] != "" {
+			continue
+		}
+		at := time.Now().UTC().Format(time.RFC3339)
+		if err := applyMigration(db, m, at); err != nil {
+			return ran, fmt.Errorf("migration %d (%s): %w", m.version, m.name, err)
+		}
+		ran = append(ran, MigrationInfo{Version: m.version, Name: m.name, AppliedAt: at})
+	}
+	return ran, nil
+}
+
+func applyMigration(db *DB, m migration, at string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := m.up(tx); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (version, name, applied_at_utc) VALUES (?,?,?)`, m.version, m.name, at); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func appliedMigrations(db *DB) (map[int]string, error) {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+  version INTEGER PRIMARY KEY,
+  name TEXT NOT NULL,
+  applied_at_utc TEXT NOT NULL
+)`); err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`SELECT version, applied_at_utc FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[int]string{}
+	for rows.Next() {
+		var v int
+		var at string
+		if err := rows.Scan(&v, &at); err != nil {
+			return nil, err
+		}
+		out[v] = at
+	}
+	return out, rows.Err()
+}
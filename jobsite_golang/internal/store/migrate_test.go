@@ -0,0 +1,211 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+
+	"jobsite/internal/model"
+	"jobsite/internal/normalize"
+)
+
+func TestMigrateFromEmpty(t *testing.T) {
+	db, err := Connect(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ran, err := Migrate(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ran) != len(migrations) {
+		t.Errorf("ran %d migrations, want %d", len(ran), len(migrations))
+	}
+	if ran, err := Migrate(db); err != nil || len(ran) != 0 {
+		t.Errorf("second Migrate ran %d, %v; want nothing", len(ran), err)
+	}
+	status, err := MigrationStatus(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range status {
+		if m.AppliedAt == "" {
+			t.Errorf("migration %d (%s) pending", m.Version, m.Name)
+		}
+	}
+}
+
+// baselineSchema is the jobs database as binaries before schema_migrations
+// left it, including employment_type, which they added on open.
+const baselineSchema = `
+CREATE TABLE jobs (
+  id INTEGER PRIMARY KEY,
+  url TEXT UNIQUE,
+  title TEXT, company TEXT, location TEXT,
+  salary_raw TEXT, salary_min_usd INTEGER, salary_max_usd INTEGER,
+  source TEXT, posted_date TEXT,
+  discovered_date TEXT NOT NULL,
+  is_remote_us INTEGER NOT NULL,
+  tags TEXT
+);
+CREATE TABLE runs (
+  run_id TEXT PRIMARY KEY,
+  started_at_utc TEXT, finished_at_utc TEXT,
+  query_count INTEGER, new_links INTEGER, pages_parsed INTEGER
+);
+ALTER TABLE jobs ADD COLUMN employment_type TEXT NOT NULL DEFAULT '';
+INSERT INTO jobs (url, title, company, location, salary_raw, salary_min_usd, salary_max_usd, source, posted_date, discovered_date, is_remote_us, tags, employment_type)
+VALUES ('https://boards.greenhouse.io/acme/jobs/1', 'Senior SDET', 'Acme', 'Remote - US', '$150,000 - $180,000', 150000, 180000, 'Greenhouse', '2026-09-30', '2026-10-01', 1, 'playwright,python', 'FULL_TIME'),
+       ('https://jobs.lever.co/globex/2', 'QA Analyst', 'Globex', 'Wichita, KS', '', NULL, NULL, 'Lever', '', '2026-10-02', 0, '', '');
+INSERT INTO runs VALUES ('run-1', '2026-10-01T06:00:00Z', '2026-10-01T06:05:00Z', 3, 12, 10);`
+
+func TestMigrateFromBaseline(t *testing.T) {
+	db, err := Connect(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(baselineSchema); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := AllJobs(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byURL := func(jobs []model.Job) map[string]model.Job {
+		m := map[string]model.Job{}
+		for _, j := range jobs {
+			m[j.URL] = j
+		}
+		return m
+	}
+	got := byURL(jobs)
+	if len(got) != 2 {
+		t.Fatalf("%d jobs after migrating, want 2", len(jobs))
+	}
+	j := got["https://boards.greenhouse.io/acme/jobs/1"]
+	if j.Title != "Senior SDET" || j.Company != "Acme" || !j.IsRemoteUS ||
+		j.SalaryMaxUSD == nil || *j.SalaryMaxUSD != 180000 || j.Tags != "playwright,python" || j.EmploymentType != "FULL_TIME" {
+		t.Errorf("job = %+v", j)
+	}
+	// New columns start at their defaults until "jobsite renormalize".
+	if j.RemoteEligibility != "unknown" || j.WorkplaceType != "" || j.Score != 0 || j.ClosedAt != "" {
+		t.Errorf("new columns = %q %q %d %q", j.RemoteEligibility, j.WorkplaceType, j.Score, j.ClosedAt)
+	}
+	if j := got["https://jobs.lever.co/globex/2"]; j.SalaryMinUSD != nil || j.Location != "Wichita, KS" {
+		t.Errorf("job = %+v", j)
+	}
+
+	var queries, mode string
+	if err := db.QueryRow(`SELECT query_count, mode FROM runs WHERE run_id='run-1'`).Scan(&queries, &mode); err != nil || queries != "3" || mode != "" {
+		t.Errorf("run = %s %q, %v", queries, mode, err)
+	}
+
+	if n, err := Renormalize(db); err != nil || n != 2 {
+		t.Fatalf("Renormalize = %d, %v", n, err)
+	}
+	if jobs, err = AllJobs(db); err != nil {
+		t.Fatal(err)
+	}
+	if j := byURL(jobs)["https://boards.greenhouse.io/acme/jobs/1"]; j.RemoteEligibility != "yes" || j.CompanyID == 0 || j.SalaryCurrency != "USD" {
+		t.Errorf("renormalized job = %+v", j)
+	}
+}
+
+func TestRenormalize(t *testing.T) {
+	db := openTest(t)
+	// Rows as an older binary left them: raw URL and company, no derived fields.
+	for _, r := range [][]string{
+		{"https://job-boards.greenhouse.io/acme/jobs/1?gh_src=x", "Sr. SDET", "ACME, Inc.", "Remote - US", ""},
+		{"https://jobs.lever.co/acme/aaaa-bbbb-cccc-dddd-eeee", "Senior SDET (Remote)", "Acme", "Remote - US", ""},
+		{"https://jobs.lever.co/globex/ffff-0000-1111-2222-3333", "QA Analyst", "Globex", "Austin, TX", "Hybrid, 3 days in office."},
+	} {
+		if _, err := db.Exec(`INSERT INTO jobs (url, title, company, location, description, salary_raw, source, posted_date, tags, discovered_date, is_remote_us) VALUES (?,?,?,?,?,'','','','','2026-10-01',0)`,
+			r[0], r[1], r[2], r[3], r[4]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	n, err := Renormalize(db)
+	if err != nil || n != 3 {
+		t.Fatalf("Renormalize = %d, %v", n, err)
+	}
+	jobs, err := AllJobs(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byURL := map[string]int{}
+	for i, j := range jobs {
+		byURL[j.URL] = i
+	}
+	gh, ok := byURL["https://boards.greenhouse.io/acme/jobs/1"]
+	if !ok {
+		t.Fatalf("URL not canonicalized: %+v", jobs)
+	}
+	if j := jobs[gh]; j.CompanyID == 0 || j.RemoteEligibility != "yes" || j.WorkplaceType != "remote" || j.Seniority != "senior" || j.RoleFamily != "sdet" {
+		t.Errorf("greenhouse job = %+v", j)
+	}
+	if j := jobs[byURL["https://jobs.lever.co/acme/aaaa-bbbb-cccc-dddd-eeee"]]; j.DuplicateOf != "https://boards.greenhouse.io/acme/jobs/1" || j.CompanyID != jobs[gh].CompanyID {
+		t.Errorf("lever job not linked: duplicate_of %q company %d", j.DuplicateOf, j.CompanyID)
+	}
+	if j := jobs[byURL["https://jobs.lever.co/globex/ffff-0000-1111-2222-3333"]]; j.RemoteEligibility != "no" || j.RoleFamily != "manual-qa" {
+		t.Errorf("globex job = %q, %q", j.RemoteEligibility, j.RoleFamily)
+	}
+}
+
+func TestRenormalizeKeepsSchemaVerdicts(t *testing.T) {
+	db := openTest(t)
+	if _, err := db.Exec(`INSERT INTO jobs (url, title, company, location, description, salary_raw, source, posted_date, tags, discovered_date, is_remote_us, remote_eligibility, remote_evidence)
+VALUES ('https://jobs.lever.co/acme/1111-2222-3333-4444', 'SDET', 'Acme', 'Remote', '', '', '', '', '', '2026-10-01', 1, 'yes', 'TELECOMMUTE, applicant location US'),
+       ('https://jobs.lever.co/acme/5555-6666-7777-8888', 'QA Lead', 'Acme', '', '', '', '', '', '', '2026-10-01', 0, 'unknown', 'TELECOMMUTE')`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Renormalize(db); err != nil {
+		t.Fatal(err)
+	}
+	jobs, err := AllJobs(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, j := range jobs {
+		switch {
+		case j.RemoteEvidence == "TELECOMMUTE, applicant location US":
+			if j.RemoteEligibility != "yes" || !j.IsRemoteUS {
+				t.Errorf("schema verdict overwritten: %+v", j)
+			}
+		// The location says nothing, so only TELECOMMUTE makes it remote.
+		case j.WorkplaceType != "remote" || j.RemoteEligibility != "unknown":
+			t.Errorf("telecommute job = %q, %q", j.WorkplaceType, j.RemoteEligibility)
+		}
+	}
+	if len(jobs) != 2 {
+		t.Errorf("%d jobs, want 2", len(jobs))
+	}
+}
+
+func TestRenormalizeSalary(t *testing.T) {
+	db := openTest(t)
+	rate := normalize.FXRates["EUR"]
+	normalize.FXRates["EUR"] = 1.5
+	t.Cleanup(func() { normalize.FXRates["EUR"] = rate })
+	// Stale columns from before EUR was recognized: taken as USD, no period.
+	if _, err := db.Exec(`INSERT INTO jobs (url, title, company, location, description, salary_raw, salary_min_usd, salary_max_usd, source, posted_date, tags, discovered_date, is_remote_us)
+VALUES ('https://jobs.lever.co/acme/1111-2222-3333-4444', 'SDET', 'Acme', 'Remote', '', '€80,000 - €100,000', 80000, 100000, '', '', '', '2026-10-01', 0)`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Renormalize(db); err != nil {
+		t.Fatal(err)
+	}
+	jobs, err := AllJobs(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j := jobs[0]
+	if j.SalaryMinUSD == nil || *j.SalaryMinUSD != 120000 || j.SalaryMaxUSD == nil || *j.SalaryMaxUSD != 150000 ||
+		j.SalaryCurrency != "EUR" || j.SalaryPeriod != "year" {
+		t.Errorf("salary = %v-%v %q %q", j.SalaryMinUSD, j.SalaryMaxUSD, j.SalaryCurrency, j.SalaryPeriod)
+	}
+}
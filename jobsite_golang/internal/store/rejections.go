@@ -0,0 +1,48 @@
+package store
+
+import (
+	"time"
+
+	"jobsite/internal/model"
+)
+
+// Rejection is a job the filter rules kept out of the jobs table.
+type Rejection struct {
+	URL        string
+	Title      string
+	Company    string
+	Location   string
+	Reason     string
+	RunID      string
+	RejectedAt string
+}
+
+// RecordRejection stores why j was rejected in run runID. A job rejected
+// again keeps only its latest reason.
+func RecordRejection(db *DB, j model.Job, reason, runID string) error {
+	_, err := db.Exec(`INSERT INTO rejections (url, title, company, location, reason, run_id, rejected_at_utc)
+VALUES (?,?,?,?,?,?,?)
+ON CONFLICT(url) DO UPDATE SET title=excluded.title, company=excluded.company, location=excluded.location,
+  reason=excluded.reason, run_id=excluded.run_id, rejected_at_utc=excluded.rejected_at_utc`,
+		j.URL, j.Title, j.Company, j.Location, reason, runID, time.Now().UTC().Format(time.RFC3339))
+	return err
+}
+
+// RecentRejections returns the last n rejections, newest first.
+func RecentRejections(db *DB, n int) ([]Rejection, error) {
+	rows, err := db.Query(`SELECT url, title, company, location, reason, run_id, rejected_at_utc
+FROM rejections ORDER BY rejected_at_utc DESC, url LIMIT ?`, n)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []Rejection
+	for rows.Next() {
+		var r Rejection
+		if err := rows.Scan(&r.URL, &r.Title, &r.Company, &r.Location, &r.Reason, &r.RunID, &r.RejectedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"jobsite/internal/normalize"
+)
+
+// fromSignals matches remote verdicts that came from schema.org or ATS API
+// signals (see normalize.SignalEvidence), which the stored text alone can't
+// reproduce.
+const fromSignals = `remote_evidence LIKE '` + normalize.SignalEvidence + `%'`
+
+// Renormalize re-derives everything the normalize package works out for a
+// job from its stored fields: the canonical URL (where that doesn't collide
+// with another row), company, salary (from salary_raw with the current
+// FXRates, where it still parses), places and workplace type (remote when
+// the location says nothing but the signals said remote), remote
+// eligibility (unless it came from those signals), seniority and role
+// family, and duplicate links, oldest job first. It returns the number of jobs processed.
+func Renormalize(db *DB) (int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	type row struct {
+		id                                int64
+		url, title, company, place, descr string
+		salary                            string
+		signals                           bool
+	}
+	rows, err := tx.Query(`SELECT id, url, ifnull(title,''), ifnull(company,''), ifnull(location,''), description, ifnull(salary_raw,''), ` + fromSignals + ` FROM jobs ORDER BY id`)
+	if err != nil {
+		return 0, err
+	}
+	var all []row
+	for rows.Next() {
+		var r row
+		if err := rows.Scan(&r.id, &r.url, &r.title, &r.company, &r.place, &r.descr, &r.salary, &r.signals); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		all = append(all, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for i, r := range all {
+		if canon := normalize.CanonicalURL(r.url); canon != r.url {
+			if _, err := tx.Exec(`UPDATE jobs SET url=? WHERE id=? AND NOT EXISTS (SELECT 1 FROM jobs WHERE url=?)`, canon, r.id, canon); err != nil {
+				return 0, err
+			}
+		}
+		if err := tx.QueryRow(`SELECT url FROM jobs WHERE id=?`, r.id).Scan(&all[i].url); err != nil {
+			return 0, err
+		}
+
+		if sal, ok := normalize.ParseSalary(r.salary); ok {
+			min, max := sal.AnnualUSD()
+			if _, err := tx.Exec(`UPDATE jobs SET salary_min_usd=?, salary_max_usd=?, salary_currency=?, salary_period=? WHERE id=?`,
+				min, max, sal.Currency, sal.Period, r.id); err != nil {
+				return 0, err
+			}
+		}
+
+		companyID, company, err := resolveCompany(tx, r.company)
+		if err != nil {
+			return 0, err
+		}
+		places, workplace := normalize.ParseLocation(r.place)
+		if workplace == "" && r.signals {
+			workplace = normalize.WorkplaceRemote
+		}
+		remote := normalize.ClassifyRemoteUS(r.place, r.title+"\n"+r.descr, false, nil)
+		seniority, family := normalize.ClassifyTitle(r.title)
+		if _, err := tx.Exec(`UPDATE jobs SET company=?, company_id=NULLIF(?,0), places=?, workplace_type=?,
+  remote_eligibility=CASE WHEN `+fromSignals+` THEN remote_eligibility ELSE ? END,
+  remote_evidence=CASE WHEN `+fromSignals+` THEN remote_evidence ELSE ? END,
+  is_remote_us=CASE WHEN `+fromSignals+` THEN is_remote_us ELSE ? END, seniority=?, role_family=?, dedupe_key=? WHERE id=?`,
+			company, companyID, placesJSON(places), workplace,
+			remote.Eligible, remote.Evidence, boolToInt(remote.Eligible == normalize.RemoteYes),
+			seniority, family, normalize.DedupeKey(company, r.place), r.id); err != nil {
+			return 0, err
+		}
+		all[i].company = company
+	}
+	for _, r := range all {
+		if _, err := linkDuplicate(tx, r.url, r.title, normalize.DedupeKey(r.company, r.place)); err != nil {
+			return 0, err
+		}
+	}
+	return len(all), tx.Commit()
+}
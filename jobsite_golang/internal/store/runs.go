@@ -0,0 +1,124 @@
+package store
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Run is one invocation of the pipeline as recorded in the runs table.
+type Run struct {
+	ID              string
+	Mode            string
+	StartedAt       string
+	FinishedAt      string
+	QueryCount      int
+	NewLinks        int // unique links found by search and ATS boards
+	PagesParsed     int
+	FetchFailures   int
+	ExtractFailures int
+	Inserted        int
+	Updated         int
+	Rejected        int    // jobs turned away by the filter rules
+	Status          string // "running", "ok" or "failed"
+	Error           string
+	Queries         []RunQuery
+}
+
+// RunQuery is the outcome of one search query within a run.
+type RunQuery struct {
+	Query        string
+	LinksFound   int
+	SearchErrors int
+}
+
+// StartRun records the start of a run in mode and returns it for the caller
+// to fill in and pass to FinishRun.
+func StartRun(db *DB, mode string) (*Run, error) {
+	now := time.Now().UTC()
+	r := &Run{
+		ID:        now.Format("20060102T150405.000Z") + "-" + mode,
+		Mode:      mode,
+		StartedAt: now.Format(time.RFC3339),
+		Status:    "running",
+	}
+	_, err := db.Exec(`INSERT INTO runs (run_id, mode, started_at_utc, status) VALUES (?,?,?,?)`, r.ID, r.Mode, r.StartedAt, r.Status)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// FinishRun stores r's counters and its final status, failed if runErr is set.
+func FinishRun(db *DB, r *Run, runErr error) error {
+	r.FinishedAt = time.Now().UTC().Format(time.RFC3339)
+	r.Status = "ok"
+	if runErr != nil {
+		r.Status = "failed"
+		r.Error = runErr.Error()
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(`UPDATE runs SET finished_at_utc=?, query_count=?, new_links=?, pages_parsed=?,
+  fetch_failures=?, extract_failures=?, inserted=?, updated=?, rejected=?, status=?, error=?
+WHERE run_id=?`,
+		r.FinishedAt, r.QueryCount, r.NewLinks, r.PagesParsed,
+		r.FetchFailures, r.ExtractFailures, r.Inserted, r.Updated, r.Rejected, r.Status, r.Error, r.ID)
+	if err != nil {
+		return err
+	}
+	for i, q := range r.Queries {
+		if _, err := tx.Exec(`INSERT INTO run_queries (run_id, position, query, links_found, search_errors) VALUES (?,?,?,?,?)`,
+			r.ID, i+1, q.Query, q.LinksFound, q.SearchErrors); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// RecentRuns returns the last n runs, newest first, with their per-query counts.
+func RecentRuns(db *DB, n int) ([]Run, error) {
+	rows, err := db.Query(`SELECT run_id, mode, started_at_utc, finished_at_utc, query_count, new_links, pages_parsed,
+  fetch_failures, extract_failures, inserted, updated, rejected, status, error
+FROM runs ORDER BY started_at_utc DESC, rowid DESC LIMIT ?`, n)
+	if err != nil {
+		return nil, err
+	}
+	var out []Run
+	for rows.Next() {
+		var r Run
+		var finished sql.NullString
+		var queries, links, pages sql.NullInt64
+		if err := rows.Scan(&r.ID, &r.Mode, &r.StartedAt, &finished, &queries, &links, &pages,
+			&r.FetchFailures, &r.ExtractFailures, &r.Inserted, &r.Updated, &r.Rejected, &r.Status, &r.Error); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		r.FinishedAt = finished.String
+		r.QueryCount, r.NewLinks, r.PagesParsed = int(queries.Int64), int(links.Int64), int(pages.Int64)
+		out = append(out, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range out {
+		qrows, err := db.Query(`SELECT query, links_found, search_errors FROM run_queries WHERE run_id=? ORDER BY position`, out[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		for qrows.Next() {
+			var q RunQuery
+			if err := qrows.Scan(&q.Query, &q.LinksFound, &q.SearchErrors); err != nil {
+				qrows.Close()
+				return nil, err
+			}
+			out[i].Queries = append(out[i].Queries, q)
+		}
+		qrows.Close()
+	}
+	return out, nil
+}
@@ -0,0 +1,61 @@
+package store
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRunsRoundTrip(t *testing.T) {
+	db := openTest(t)
+	daily, err := StartRun(db, "daily")
+	if err != nil {
+		t.Fatal(err)
+	}
+	daily.QueryCount, daily.NewLinks, daily.PagesParsed = 2, 15, 12
+	daily.FetchFailures, daily.ExtractFailures = 2, 1
+	daily.Inserted, daily.Updated, daily.Rejected = 7, 3, 2
+	daily.Queries = []RunQuery{
+		{Query: "site:boards.greenhouse.io sdet remote", LinksFound: 10},
+		{Query: "site:jobs.lever.co qa engineer", LinksFound: 5, SearchErrors: 1},
+	}
+	if err := FinishRun(db, daily, nil); err != nil {
+		t.Fatal(err)
+	}
+	recheck, err := StartRun(db, "recheck")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := FinishRun(db, recheck, errors.New("database is locked")); err != nil {
+		t.Fatal(err)
+	}
+	running, err := StartRun(db, "weekly")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := RecentRuns(db, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 2 || runs[0].ID != running.ID || runs[1].ID != recheck.ID {
+		t.Fatalf("RecentRuns(2) = %+v, want weekly then recheck", runs)
+	}
+	if r := runs[0]; r.Status != "running" || r.FinishedAt != "" || r.Mode != "weekly" {
+		t.Errorf("unfinished run = %+v", r)
+	}
+	if r := runs[1]; r.Status != "failed" || r.Error != "database is locked" || r.FinishedAt == "" || len(r.Queries) != 0 {
+		t.Errorf("failed run = %+v", r)
+	}
+
+	runs, err = RecentRuns(db, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 3 {
+		t.Fatalf("RecentRuns(10) returned %d runs", len(runs))
+	}
+	if !reflect.DeepEqual(runs[2], *daily) {
+		t.Errorf("daily run\n got %+v\nwant %+v", runs[2], *daily)
+	}
+}
@@ -2,15 +2,32 @@ package store
 
 import (
 	"database/sql"
+	"encoding/json"
 	_ "github.com/mattn/go-sqlite3"
+	"strings"
 	"time"
 
 	"jobsite/internal/model"
+	"jobsite/internal/normalize"
 )
 
 type DB struct{ *sql.DB }
 
+// Open opens the database at path and applies any pending migrations.
 func Open(path string) (*DB, error) {
+	db, err := Connect(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := Migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Connect opens the database at path without touching its schema.
+func Connect(path string) (*DB, error) {
 	db, err := sql.Open("sqlite3", path)
 	if err != nil {
 		return nil, err
@@ -26,31 +43,15 @@ func Open(path string) (*DB, error) {
 	if _, err := db.Exec(`PRAGMA foreign_keys=ON;`); err != nil {
 		return nil, err
 	}
+	return &DB{db}, nil
+}
 
-	_, err = db.Exec(`
-CREATE TABLE IF NOT EXISTS jobs (
-  id INTEGER PRIMARY KEY,
-  url TEXT UNIQUE,
-  title TEXT, company TEXT, location TEXT,
-  salary_raw TEXT, salary_min_usd INTEGER, salary_max_usd INTEGER,
-  source TEXT, posted_date TEXT,
-  discovered_date TEXT NOT NULL,
-  is_remote_us INTEGER NOT NULL,
-  tags TEXT
-);
-CREATE TABLE IF NOT EXISTS runs (
-  run_id TEXT PRIMARY KEY,
-  started_at_utc TEXT, finished_at_utc TEXT,
-  query_count INTEGER, new_links INTEGER, pages_parsed INTEGER
-);`)
-	return &DB{db}, err
-}
-
-// InsertJob inserts or updates a job. On conflict, updates all fields except discovered_date
-func InsertJob(db *DB, j model.Job) error {
-	result, err := db.Exec(`INSERT INTO jobs
-(url,title,company,location,salary_raw,salary_min_usd,salary_max_usd,source,posted_date,discovered_date,is_remote_us,tags)
-VALUES (?,?,?,?,?,?,?,?,?,?,?,?)
+// upsertJobSQL inserts a job or, on a URL conflict, updates every field except
+// discovered_date and reopens it. A refetch that found no description keeps
+// the stored one.
+const upsertJobSQL = `INSERT INTO jobs
+(url,title,company,location,salary_raw,salary_min_usd,salary_max_usd,salary_currency,salary_period,source,posted_date,discovered_date,is_remote_us,tags,employment_type,description,description_html,places,workplace_type,remote_eligibility,remote_evidence,dedupe_key,company_id,seniority,role_family,score,score_explanation)
+VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)
 ON CONFLICT(url) DO UPDATE SET
   title=excluded.title,
   company=excluded.company,
@@ -58,12 +59,43 @@ ON CONFLICT(url) DO UPDATE SET
   salary_raw=excluded.salary_raw,
   salary_min_usd=excluded.salary_min_usd,
   salary_max_usd=excluded.salary_max_usd,
+  salary_currency=excluded.salary_currency,
+  salary_period=excluded.salary_period,
   source=excluded.source,
   posted_date=excluded.posted_date,
   is_remote_us=excluded.is_remote_us,
-  tags=excluded.tags`,
-		j.URL, j.Title, j.Company, j.Location, j.SalaryRaw, j.SalaryMinUSD, j.SalaryMaxUSD,
-		j.Source, j.PostedDate, j.DiscoveredDate, boolToInt(j.IsRemoteUS), j.Tags)
+  tags=excluded.tags,
+  employment_type=excluded.employment_type,
+  description=CASE WHEN excluded.description<>'' THEN excluded.description ELSE jobs.description END,
+  description_html=CASE WHEN excluded.description<>'' THEN excluded.description_html ELSE jobs.description_html END,
+  places=excluded.places,
+  workplace_type=excluded.workplace_type,
+  remote_eligibility=excluded.remote_eligibility,
+  remote_evidence=excluded.remote_evidence,
+  dedupe_key=excluded.dedupe_key,
+  company_id=excluded.company_id,
+  seniority=excluded.seniority,
+  role_family=excluded.role_family,
+  score=excluded.score,
+  score_explanation=excluded.score_explanation,
+  closed_at=NULL`
+
+func upsertJobArgs(j model.Job) []any {
+	return []any{j.URL, j.Title, j.Company, j.Location, j.SalaryRaw, j.SalaryMinUSD, j.SalaryMaxUSD,
+		j.SalaryCurrency, j.SalaryPeriod, j.Source, j.PostedDate, j.DiscoveredDate, boolToInt(j.IsRemoteUS), j.Tags, j.EmploymentType,
+		j.Description, j.DescriptionHTML, placesJSON(j.Places), j.WorkplaceType, remoteEligibility(j), j.RemoteEvidence,
+		normalize.DedupeKey(j.Company, j.Location), sql.NullInt64{Int64: j.CompanyID, Valid: j.CompanyID != 0},
+		j.Seniority, j.RoleFamily, j.Score, j.ScoreExplanation}
+}
+
+// InsertJob inserts or updates a job. On conflict, updates all fields except
+// discovered_date and reopens the job if it had been marked closed.
+func InsertJob(db *DB, j model.Job) error {
+	var err error
+	if j.CompanyID, j.Company, err = resolveCompany(db, j.Company); err != nil {
+		return err
+	}
+	result, err := db.Exec(upsertJobSQL, upsertJobArgs(j)...)
 
 	// Track if this was a new insert or update
 	if err == nil {
@@ -73,49 +105,101 @@ ON CONFLICT(url) DO UPDATE SET
 		} else {
 			// Updated existing record
 		}
+		_, err = linkDuplicate(db, j.URL, j.Title, normalize.DedupeKey(j.Company, j.Location))
 	}
 	return err
 }
 
 // InsertJobStats tracks upsert statistics
 type InsertJobStats struct {
-	Inserted int64
-	Updated  int64
+	Inserted    int64
+	Updated     int64
+	DuplicateOf string // URL of the job this one duplicates, if any
 }
 
 // InsertJobWithStats performs upsert and returns counts
 func InsertJobWithStats(db *DB, j model.Job) (InsertJobStats, error) {
-	result, err := db.Exec(`INSERT INTO jobs
-(url,title,company,location,salary_raw,salary_min_usd,salary_max_usd,source,posted_date,discovered_date,is_remote_us,tags)
-VALUES (?,?,?,?,?,?,?,?,?,?,?,?)
-ON CONFLICT(url) DO UPDATE SET
-  title=excluded.title,
-  company=excluded.company,
-  location=excluded.location,
-  salary_raw=excluded.salary_raw,
-  salary_min_usd=excluded.salary_min_usd,
-  salary_max_usd=excluded.salary_max_usd,
-  source=excluded.source,
-  posted_date=excluded.posted_date,
-  is_remote_us=excluded.is_remote_us,
-  tags=excluded.tags`,
-		j.URL, j.Title, j.Company, j.Location, j.SalaryRaw, j.SalaryMinUSD, j.SalaryMaxUSD,
-		j.Source, j.PostedDate, j.DiscoveredDate, boolToInt(j.IsRemoteUS), j.Tags)
-
 	var stats InsertJobStats
-	if err == nil {
-		lastID, _ := result.LastInsertId()
-		rowsAffected, _ := result.RowsAffected()
+	var err error
+	if j.CompanyID, j.Company, err = resolveCompany(db, j.Company); err != nil {
+		return stats, err
+	}
+	// The upsert's LastInsertId is set on updates too, so look first.
+	var exists bool
+	if err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM jobs WHERE url=?)`, j.URL).Scan(&exists); err != nil {
+		return stats, err
+	}
+	_, err = db.Exec(upsertJobSQL, upsertJobArgs(j)...)
 
-		if lastID > 0 {
-			stats.Inserted = 1
+	if err == nil {
+		if exists {
+			stats.Updated = 1
 		} else {
-			stats.Updated = rowsAffected
+			stats.Inserted = 1
 		}
+		stats.DuplicateOf, err = linkDuplicate(db, j.URL, j.Title, normalize.DedupeKey(j.Company, j.Location))
 	}
 	return stats, err
 }
 
+// execQuerier is what linkDuplicate needs from a *DB or an *sql.Tx.
+type execQuerier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// linkDuplicate points the job stored under url at the oldest open job
+// sharing its dedupe key (see normalize.DedupeKey) whose title is similar,
+// or clears the link when there is none. It returns the primary's URL.
+// A job without a key is never linked.
+func linkDuplicate(q execQuerier, url, title, key string) (string, error) {
+	if key == "" {
+		_, err := q.Exec(`UPDATE jobs SET duplicate_of=NULL WHERE url=?`, url)
+		return "", err
+	}
+	rows, err := q.Query(`SELECT url, ifnull(title,'') FROM jobs
+WHERE dedupe_key=? AND url<>? AND duplicate_of IS NULL AND closed_at IS NULL
+  AND id < (SELECT id FROM jobs WHERE url=?) ORDER BY id`, key, url, url)
+	if err != nil {
+		return "", err
+	}
+	var primary string
+	for rows.Next() {
+		var u, t string
+		if err := rows.Scan(&u, &t); err != nil {
+			rows.Close()
+			return "", err
+		}
+		if normalize.SimilarTitles(title, t) {
+			primary = u
+			break
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	_, err = q.Exec(`UPDATE jobs SET duplicate_of=NULLIF(?,'') WHERE url=?`, primary, url)
+	return primary, err
+}
+
+// placesJSON encodes places for the places column, always as an array.
+func placesJSON(places []model.Place) string {
+	if len(places) == 0 {
+		return "[]"
+	}
+	b, _ := json.Marshal(places)
+	return string(b)
+}
+
+// remoteEligibility is j's verdict, or "unknown" for jobs never classified.
+func remoteEligibility(j model.Job) string {
+	if j.RemoteEligibility == "" {
+		return normalize.RemoteUnknown
+	}
+	return j.RemoteEligibility
+}
+
 func boolToInt(b bool) int {
 	if b {
 		return 1
@@ -123,21 +207,58 @@ func boolToInt(b bool) int {
 	return 0
 }
 
+// jobColumns is the column list scanJobs expects, in order.
+const jobColumns = `url,title,company,location,salary_raw,salary_min_usd,salary_max_usd,salary_currency,salary_period,source,posted_date,discovered_date,is_remote_us,tags,employment_type,closed_at,description,description_html,places,workplace_type,remote_eligibility,remote_evidence,duplicate_of,company_id,seniority,role_family,score,score_explanation`
+
+// listed keeps duplicates out of listings while their primary is open.
+const listed = `NOT EXISTS (SELECT 1 FROM jobs p WHERE p.url=jobs.duplicate_of AND p.closed_at IS NULL)`
+
 func LastNDays(db *DB, days int) ([]model.Job, error) {
-	rows, err := db.Query(`SELECT url,title,company,location,salary_raw,salary_min_usd,salary_max_usd,source,posted_date,discovered_date,is_remote_us,tags
-FROM jobs WHERE date(discovered_date) >= date(?, '-'||?||' day') ORDER BY discovered_date DESC`, time.Now().UTC().Format("2006-01-02"), days-1)
+	rows, err := db.Query(`SELECT `+jobColumns+`
+FROM jobs WHERE date(discovered_date) >= date(?, '-'||?||' day') AND closed_at IS NULL AND `+listed+` ORDER BY discovered_date DESC`, time.Now().UTC().Format("2006-01-02"), days-1)
+	if err != nil {
+		return nil, err
+	}
+	return scanJobs(rows)
+}
+
+// DiscoveredBetween returns jobs first seen on dates from..to inclusive
+// (YYYY-MM-DD), including ones closed since.
+func DiscoveredBetween(db *DB, from, to string) ([]model.Job, error) {
+	rows, err := db.Query(`SELECT `+jobColumns+`
+FROM jobs WHERE date(discovered_date) BETWEEN date(?) AND date(?) AND `+listed+` ORDER BY discovered_date DESC, company`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return scanJobs(rows)
+}
+
+// ClosedBetween returns jobs marked closed on dates from..to inclusive
+// (YYYY-MM-DD), whenever they were discovered.
+func ClosedBetween(db *DB, from, to string) ([]model.Job, error) {
+	rows, err := db.Query(`SELECT `+jobColumns+`
+FROM jobs WHERE closed_at IS NOT NULL AND date(closed_at) BETWEEN date(?) AND date(?) AND `+listed+` ORDER BY closed_at DESC`, from, to)
 	if err != nil {
 		return nil, err
 	}
+	return scanJobs(rows)
+}
+
+func scanJobs(rows *sql.Rows) ([]model.Job, error) {
 	defer rows.Close()
 	var out []model.Job
 	for rows.Next() {
 		var j model.Job
 		var min, max sql.NullInt64
 		var remote int
-		if err := rows.Scan(&j.URL, &j.Title, &j.Company, &j.Location, &j.SalaryRaw, &min, &max, &j.Source, &j.PostedDate, &j.DiscoveredDate, &remote, &j.Tags); err != nil {
+		var closedAt sql.NullString
+		var places string
+		var duplicateOf sql.NullString
+		var companyID sql.NullInt64
+		if err := rows.Scan(&j.URL, &j.Title, &j.Company, &j.Location, &j.SalaryRaw, &min, &max, &j.SalaryCurrency, &j.SalaryPeriod, &j.Source, &j.PostedDate, &j.DiscoveredDate, &remote, &j.Tags, &j.EmploymentType, &closedAt, &j.Description, &j.DescriptionHTML, &places, &j.WorkplaceType, &j.RemoteEligibility, &j.RemoteEvidence, &duplicateOf, &companyID, &j.Seniority, &j.RoleFamily, &j.Score, &j.ScoreExplanation); err != nil {
 			return nil, err
 		}
+		json.Unmarshal([]byte(places), &j.Places)
 		if min.Valid {
 			v := int(min.Int64)
 			j.SalaryMinUSD = &v
@@ -147,7 +268,81 @@ FROM jobs WHERE date(discovered_date) >= date(?, '-'||?||' day') ORDER BY discov
 			j.SalaryMaxUSD = &v
 		}
 		j.IsRemoteUS = remote == 1
+		j.ClosedAt = closedAt.String
+		j.DuplicateOf = duplicateOf.String
+		j.CompanyID = companyID.Int64
 		out = append(out, j)
 	}
-	return out, nil
+	return out, rows.Err()
+}
+
+// OpenURLs returns the URLs of jobs discovered in the last days days that
+// have not been marked closed.
+func OpenURLs(db *DB, days int) ([]string, error) {
+	rows, err := db.Query(`SELECT url FROM jobs
+WHERE date(discovered_date) >= date(?, '-'||?||' day') AND closed_at IS NULL ORDER BY discovered_date DESC`, time.Now().UTC().Format("2006-01-02"), days-1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []string
+	for rows.Next() {
+		var u string
+		if err := rows.Scan(&u); err != nil {
+			return nil, err
+		}
+		out = append(out, u)
+	}
+	return out, rows.Err()
+}
+
+// MarkClosed records that the posting at url was taken down at closedAt.
+func MarkClosed(db *DB, url string, closedAt time.Time) error {
+	_, err := db.Exec(`UPDATE jobs SET closed_at=? WHERE url=? AND closed_at IS NULL`, closedAt.UTC().Format(time.RFC3339), url)
+	return err
+}
+
+// AllJobs returns every stored job, newest first.
+func AllJobs(db *DB) ([]model.Job, error) {
+	rows, err := db.Query(`SELECT ` + jobColumns + ` FROM jobs ORDER BY discovered_date DESC`)
+	if err != nil {
+		return nil, err
+	}
+	return scanJobs(rows)
+}
+
+// Search returns open jobs whose title, company, location, tags or description
+// contain every one of terms, case-insensitively, newest first.
+func Search(db *DB, terms []string) ([]model.Job, error) {
+	q := `SELECT ` + jobColumns + ` FROM jobs WHERE closed_at IS NULL AND ` + listed
+	var args []any
+	for _, t := range terms {
+		q += ` AND (ifnull(title,'') || ' ' || ifnull(company,'') || ' ' || ifnull(location,'') || ' ' || ifnull(tags,'') || ' ' || description) LIKE ? ESCAPE '\'`
+		args = append(args, "%"+likeEscaper.Replace(t)+"%")
+	}
+	rows, err := db.Query(q+` ORDER BY discovered_date DESC`, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanJobs(rows)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// UpdateDescription stores a description fetched after the job was saved.
+func UpdateDescription(db *DB, url, text, html string) error {
+	_, err := db.Exec(`UPDATE jobs SET description=?, description_html=? WHERE url=?`, text, html, url)
+	return err
+}
+
+// UpdateScore replaces the score of the job stored under url.
+func UpdateScore(db *DB, url string, score int, explanation string) error {
+	_, err := db.Exec(`UPDATE jobs SET score=?, score_explanation=? WHERE url=?`, score, explanation, url)
+	return err
+}
+
+// UpdateTags replaces the tags of the job stored under url.
+func UpdateTags(db *DB, url, tags string) error {
+	_, err := db.Exec(`UPDATE jobs SET tags=? WHERE url=?`, tags, url)
+	return err
 }
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"jobsite/internal/company"
+	"jobsite/internal/model"
+)
+
+func openTest(t *testing.T) *DB {
+	t.Helper()
+	db, err := Open(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func testJob(url, title, company string) model.Job {
+	return model.Job{URL: url, Title: title, Company: company, Location: "Remote - US",
+		DiscoveredDate: time.Now().UTC().Format("2006-01-02")}
+}
+
+func TestDuplicatesNeedACompany(t *testing.T) {
+	db := openTest(t)
+	for _, j := range []model.Job{
+		testJob("https://a.example/jobs/1", "QA Engineer", ""),
+		testJob("https://b.example/jobs/2", "QA Engineer", ""),
+	} {
+		stats, err := InsertJobWithStats(db, j)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.DuplicateOf != "" {
+			t.Errorf("%s linked to %s", j.URL, stats.DuplicateOf)
+		}
+	}
+	jobs, err := LastNDays(db, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 2 {
+		t.Errorf("listed %d jobs, want 2", len(jobs))
+	}
+}
+
+func TestDuplicatesLinkedWithinCompany(t *testing.T) {
+	db := openTest(t)
+	if _, err := InsertJobWithStats(db, testJob("https://boards.greenhouse.io/acme/jobs/1", "Senior QA Engineer", "Acme")); err != nil {
+		t.Fatal(err)
+	}
+	stats, err := InsertJobWithStats(db, testJob("https://acme.com/careers?gh_jid=1", "Sr. QA Engineer", "Acme, Inc."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.DuplicateOf != "https://boards.greenhouse.io/acme/jobs/1" {
+		t.Errorf("DuplicateOf = %q", stats.DuplicateOf)
+	}
+}
+
+func TestInsertJobWithStatsCounts(t *testing.T) {
+	db := openTest(t)
+	j := testJob("https://jobs.lever.co/acme/1", "QA Engineer", "Acme")
+	for i, want := range []InsertJobStats{{Inserted: 1}, {Updated: 1}, {Updated: 1}} {
+		got, err := InsertJobWithStats(db, j)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Inserted != want.Inserted || got.Updated != want.Updated {
+			t.Errorf("upsert %d: got {Inserted:%d Updated:%d}, want {Inserted:%d Updated:%d}", i+1, got.Inserted, got.Updated, want.Inserted, want.Updated)
+		}
+		j.Title = "Senior QA Engineer"
+	}
+}
+
+func TestLookupCompanyFollowsAliases(t *testing.T) {
+	db := openTest(t)
+	if err := SetCompanyAliases(db, company.Aliases{"Acme": {"Road Runner Supply"}}); err != nil {
+		t.Fatal(err)
+	}
+	acme, _, err := LookupCompany(db, "Acme, Inc.")
+	if err != nil || acme == 0 {
+		t.Fatalf("LookupCompany(Acme, Inc.) = %d, %v", acme, err)
+	}
+	alias, name, err := LookupCompany(db, "Road Runner Supply")
+	if err != nil || alias != acme || name != "Acme" {
+		t.Errorf("LookupCompany(alias) = %d %q %v, want %d Acme", alias, name, err, acme)
+	}
+	if id, _, err := LookupCompany(db, "Globex"); err != nil || id != 0 {
+		t.Errorf("LookupCompany(unknown) = %d, %v", id, err)
+	}
+}
@@ -0,0 +1,105 @@
+package tags
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Vocabulary maps a canonical tag to the phrases that count as a mention of it.
+type Vocabulary map[string][]string
+
+var defaultVocabulary = Vocabulary{
+	"appium":         {"appium"},
+	"playwright":     {"playwright"},
+	"selenium":       {"selenium", "webdriver"},
+	"cypress":        {"cypress"},
+	"webdriverio":    {"webdriverio", "wdio"},
+	"ios":            {"ios", "xcuitest", "xctest", "xcode"},
+	"android":        {"android", "espresso", "ui automator", "uiautomator"},
+	"macos":          {"macos", "mac os", "os x", "osx"},
+	"github-actions": {"github actions", "gh actions", "github workflows"},
+	"ci-cd":          {"ci/cd", "ci cd", "cicd", "continuous integration", "continuous delivery", "continuous deployment"},
+	"jenkins":        {"jenkins"},
+	"python":         {"python", "pytest"},
+	"java":           {"java", "testng", "junit"},
+	"javascript":     {"javascript", "node.js", "nodejs"},
+	"typescript":     {"typescript"},
+	"golang":         {"golang"},
+	"kotlin":         {"kotlin"},
+	"swift":          {"swift"},
+	"api-testing":    {"api testing", "api automation", "rest assured", "restassured", "postman"},
+	"performance":    {"performance testing", "load testing", "jmeter", "gatling", "k6", "locust"},
+	"docker":         {"docker"},
+	"kubernetes":     {"kubernetes", "k8s"},
+	"aws":            {"aws", "amazon web services"},
+}
+
+// Default returns a copy of the built-in QA/SDET skills vocabulary.
+func Default() Vocabulary {
+	v := make(Vocabulary, len(defaultVocabulary))
+	for tag, syns := range defaultVocabulary {
+		v[tag] = append([]string(nil), syns...)
+	}
+	return v
+}
+
+// Load reads a vocabulary from a JSON object of tag → synonyms.
+func Load(path string) (Vocabulary, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var v Vocabulary
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Matcher finds vocabulary tags in free text. Build one with NewMatcher and
+// reuse it; its patterns are compiled once.
+type Matcher struct {
+	tags []string
+	pats map[string]*regexp.Regexp
+}
+
+// NewMatcher compiles v into a Matcher. The tag itself always counts as a
+// synonym, and tags that differ only in case or surrounding space are merged.
+func NewMatcher(v Vocabulary) *Matcher {
+	alts := map[string][]string{}
+	for tag, syns := range v {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		alts[tag] = append(alts[tag], regexp.QuoteMeta(tag))
+		for _, s := range syns {
+			if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+				alts[tag] = append(alts[tag], regexp.QuoteMeta(s))
+			}
+		}
+	}
+	m := &Matcher{pats: map[string]*regexp.Regexp{}}
+	for tag, a := range alts {
+		// Match whole words only so "go" in "google" or "k6" in "k64" don't count.
+		m.pats[tag] = regexp.MustCompile(`(?i)(?:^|[^\w])(?:` + strings.Join(a, "|") + `)(?:$|[^\w])`)
+		m.tags = append(m.tags, tag)
+	}
+	sort.Strings(m.tags)
+	return m
+}
+
+// Match returns the comma-separated, sorted tags mentioned in any of texts.
+func (m *Matcher) Match(texts ...string) string {
+	full := strings.Join(texts, "\n")
+	var out []string
+	for _, tag := range m.tags {
+		if m.pats[tag].MatchString(full) {
+			out = append(out, tag)
+		}
+	}
+	return strings.Join(out, ",")
+}
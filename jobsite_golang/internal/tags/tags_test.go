@@ -0,0 +1,49 @@
+package tags
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	m := NewMatcher(Default())
+	tests := []struct{ text, want string }{
+		{"Experience with GH Actions pipelines", "github-actions"},
+		{"We use GitHub Actions and Jenkins", "github-actions,jenkins"},
+		{"Strong XCUITest skills", "ios"},
+		{"iOS and Android apps via Appium", "android,appium,ios"},
+		{"Build CI/CD with Docker on K8s", "ci-cd,docker,kubernetes"},
+		{"Playwright, Cypress or WebdriverIO", "cypress,playwright,webdriverio"},
+		{"pytest and TestNG", "java,python"},
+		// Whole words only.
+		{"Work at Google on our k64 boards", ""},
+		{"javascript-heavy frontend", "javascript"},
+		{"Swiftly ship; biosciences; iOSX", ""},
+		{"Node.js services", "javascript"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := m.Match(tt.text); got != tt.want {
+			t.Errorf("Match(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+	if got := m.Match("Senior SDET", "uses playwright", "and k6"); got != "performance,playwright" {
+		t.Errorf("Match over several texts = %q", got)
+	}
+}
+
+func TestNewMatcherMergesCaseVariants(t *testing.T) {
+	m := NewMatcher(Vocabulary{
+		"iOS":   {"xcuitest"},
+		"ios":   {"swiftui"},
+		" Go ":  {"golang"},
+		"":      {"ignored"},
+		"maven": nil,
+	})
+	if len(m.tags) != 3 {
+		t.Errorf("tags = %v, want go, ios, maven once each", m.tags)
+	}
+	if got := m.Match("XCUITest and SwiftUI in Golang, built with Maven"); got != "go,ios,maven" {
+		t.Errorf("Match = %q", got)
+	}
+	if got := m.Match("ignored"); got != "" {
+		t.Errorf("empty tag matched: %q", got)
+	}
+}